@@ -0,0 +1,200 @@
+package svg
+
+// For more information on the area and moments of closed curves (Green's theorem):
+// - https://en.wikipedia.org/wiki/Green%27s_theorem#Area_calculation
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Orientation represents the winding direction of a closed contour, in a y-up coordinate system
+// since the SVG coordinate system is y-down, contours appear mirrored on screen
+// (e.g. a CounterClockwise contour is drawn clockwise)
+type Orientation int
+
+const (
+	// Degenerate is the orientation of contours without area
+	Degenerate Orientation = iota
+	// Clockwise is the orientation of contours with negative signed area
+	Clockwise
+	// CounterClockwise is the orientation of contours with positive signed area
+	CounterClockwise
+)
+
+// 6-point Gauss–Legendre abscissae and weights, in the range [-1, 1]
+// exact for polynomials up to degree 11, which covers every moment integrand of a cubic curve
+var (
+	momentAbscissae = [...]float64{
+		-0.2386191860831969086305017, 0.2386191860831969086305017,
+		-0.6612093864662645136613996, 0.6612093864662645136613996,
+		-0.9324695142031520278123016, 0.9324695142031520278123016,
+	}
+	momentWeights = [...]float64{
+		0.4679139345726910473898703, 0.4679139345726910473898703,
+		0.3607615730481386075698335, 0.3607615730481386075698335,
+		0.1713244923791703450402961, 0.1713244923791703450402961,
+	}
+)
+
+// Moments represents the area properties of a closed path
+type Moments struct {
+	// Area contains the signed area, positive for counter-clockwise contours
+	Area float64
+	// Centroid contains the centre of the area
+	Centroid vector.Vector2
+	// Ixx, Iyy and Ixy contain the second moments of area about the axes through the centroid,
+	// with the same sign as the area; Ixx + Iyy is the polar moment
+	Ixx, Iyy, Ixy float64
+}
+
+// rawMoments represents the moments of area about the origin
+type rawMoments struct {
+	// integrals of 1, x, y, x^2, y^2 and xy over the area
+	area, x, y, xx, yy, xy float64
+}
+
+// add returns the sum of both moments
+func (m rawMoments) add(other rawMoments) rawMoments {
+	return rawMoments{
+		area: m.area + other.area,
+		x:    m.x + other.x,
+		y:    m.y + other.y,
+		xx:   m.xx + other.xx,
+		yy:   m.yy + other.yy,
+		xy:   m.xy + other.xy,
+	}
+}
+
+// moments returns the contribution of the curve to the moments of area of a closed contour, using Green's theorem
+// since the integrands are polynomials of degree 11 at most, the Gauss–Legendre quadrature is exact
+func (p PathData) moments() rawMoments {
+	var m rawMoments
+	for i, x := range momentAbscissae {
+		t := 0.5 * (x + 1)
+		w := 0.5 * momentWeights[i]
+		point := p.Point(t)
+		d := p.Derivative(t)
+
+		m.area += w * 0.5 * (point.X*d.Y - point.Y*d.X)
+		m.x += w * 0.5 * point.X * point.X * d.Y
+		m.y -= w * 0.5 * point.Y * point.Y * d.X
+		m.xx += w * point.X * point.X * point.X / 3 * d.Y
+		m.yy -= w * point.Y * point.Y * point.Y / 3 * d.X
+		m.xy += w * 0.5 * point.X * point.X * point.Y * d.Y
+	}
+
+	return m
+}
+
+// rawMoments returns the moments of area of the path about the origin
+// open subpaths are implicitly closed by a straight line
+func (p Path) rawMoments() rawMoments {
+	var m rawMoments
+	for _, subpath := range p.Subpaths() {
+		for _, data := range subpath.Data {
+			m = m.add(data.moments())
+		}
+		if !subpath.IsClosed() {
+			m = m.add(newLine(subpath.Data[len(subpath.Data)-1].End, subpath.Data[0].Start).moments())
+		}
+	}
+
+	return m
+}
+
+// Area returns the signed area enclosed by the path, exact for cubic curves
+// it is positive for counter-clockwise contours and negative for clockwise ones (in a y-up coordinate system);
+// open subpaths are implicitly closed by a straight line
+func (p Path) Area() float64 {
+	return p.rawMoments().area
+}
+
+// Centroid returns the centre of the area enclosed by the path
+// degenerate paths, without area, return the origin
+func (p Path) Centroid() vector.Vector2 {
+	return p.Moments().Centroid
+}
+
+// Moments returns the signed area, the centroid and the second moments of area of the path
+func (p Path) Moments() Moments {
+	m := p.rawMoments()
+	if math.Abs(m.area) <= p.areaTolerance() {
+		return Moments{Area: m.area}
+	}
+
+	centroid := vector.Vector2{X: m.x / m.area, Y: m.y / m.area}
+	// parallel axis theorem, moving the axes from the origin to the centroid
+	return Moments{
+		Area:     m.area,
+		Centroid: centroid,
+		Ixx:      m.yy - m.area*centroid.Y*centroid.Y,
+		Iyy:      m.xx - m.area*centroid.X*centroid.X,
+		Ixy:      m.xy - m.area*centroid.X*centroid.Y,
+	}
+}
+
+// Orientation returns the winding direction of the path, given by the sign of its area
+func (p Path) Orientation() Orientation {
+	return orientation(p.Area(), p.areaTolerance())
+}
+
+// orientation returns the orientation given by the sign of a signed area, beyond the given tolerance
+func orientation(area, tolerance float64) Orientation {
+	switch {
+	case area > tolerance:
+		return CounterClockwise
+	case area < -tolerance:
+		return Clockwise
+	}
+	return Degenerate
+}
+
+// areaTolerance returns the area below which the path is degenerate, relative to the size of its bounding box
+// so that a shape is degenerate or not regardless of its scale
+func (p Path) areaTolerance() float64 {
+	size := p.Bounds().Size()
+	extent := math.Max(size.X, size.Y)
+	return epsilon * extent * extent
+}
+
+// NormalizeOrientation returns a copy of the path whose outer contours (subpaths) have the given orientation
+// and whose holes have the opposite one; a contour is a hole when it lies inside an odd number of other contours
+func (p Path) NormalizeOrientation(outer Orientation) Path {
+	subpaths := p.Subpaths()
+	normalized := p
+	normalized.Data = nil
+	for i, subpath := range subpaths {
+		// counts the contours enclosing this one, testing a point on it
+		depth := 0
+		point := subpath.Data[0].Point(0.5)
+		for j, other := range subpaths {
+			if i != j && other.Winding(point) != 0 {
+				depth++
+			}
+		}
+
+		want := outer
+		if depth%2 != 0 {
+			want = oppositeOrientation(outer)
+		}
+		if current := subpath.Orientation(); current != Degenerate && current != want {
+			subpath = subpath.Reverse()
+		}
+		normalized.Data = append(normalized.Data, subpath.Data...)
+	}
+
+	return normalized
+}
+
+// oppositeOrientation returns the opposite winding direction
+func oppositeOrientation(o Orientation) Orientation {
+	switch o {
+	case Clockwise:
+		return CounterClockwise
+	case CounterClockwise:
+		return Clockwise
+	}
+	return Degenerate
+}
@@ -0,0 +1,73 @@
+package svg
+
+import (
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+func TestMoments(t *testing.T) {
+	// a 4 by 2 rectangle, counter-clockwise in a y-up coordinate system
+	rectangle := polygonPath(vector.Vector2{X: 1, Y: 3}, vector.Vector2{X: 5, Y: 3}, vector.Vector2{X: 5, Y: 5}, vector.Vector2{X: 1, Y: 5})
+
+	tests := []struct {
+		name        string
+		path        Path
+		orientation Orientation
+		// sign of the area and the second moments
+		sign float64
+	}{
+		{"counter-clockwise", rectangle, CounterClockwise, 1},
+		{"clockwise", rectangle.Reverse(), Clockwise, -1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := test.path.Moments()
+			if !approxEqual(m.Area, test.sign*8, 1e-9) || !approxEqual(test.path.Area(), m.Area, 1e-9) {
+				t.Errorf("Area = %v, expected %v", m.Area, test.sign*8)
+			}
+			if centroid := (vector.Vector2{X: 3, Y: 4}); !approxPoint(m.Centroid, centroid, 1e-9) || !approxPoint(test.path.Centroid(), centroid, 1e-9) {
+				t.Errorf("Centroid = %v, expected %v", m.Centroid, centroid)
+			}
+			// b*h^3/12 about the horizontal axis and h*b^3/12 about the vertical one
+			if !approxEqual(m.Ixx, test.sign*8.0/3, 1e-9) || !approxEqual(m.Iyy, test.sign*32.0/3, 1e-9) || !approxEqual(m.Ixy, 0, 1e-9) {
+				t.Errorf("Ixx, Iyy, Ixy = %v, %v, %v, expected %v, %v, 0", m.Ixx, m.Iyy, m.Ixy, test.sign*8.0/3, test.sign*32.0/3)
+			}
+			if orientation := test.path.Orientation(); orientation != test.orientation {
+				t.Errorf("Orientation() = %v, expected %v", orientation, test.orientation)
+			}
+		})
+	}
+}
+
+func TestOrientationScale(t *testing.T) {
+	var zero Orientation
+	if zero != Degenerate {
+		t.Errorf("the zero value is %v, expected Degenerate", zero)
+	}
+
+	tests := []struct {
+		name        string
+		path        Path
+		orientation Orientation
+	}{
+		// a square of a micrometre in a document measured in metres still has an orientation
+		{"tiny square", square(0, 0, 1e-6), CounterClockwise},
+		// a triangle a million times longer than it is high has an orientation
+		{"thin triangle", polygonPath(vector.Vector2{}, vector.Vector2{X: 1e6}, vector.Vector2{X: 1e6, Y: 1}), CounterClockwise},
+		// while one a hundred billion times longer is only a rounding error away from a line
+		{"flat triangle", polygonPath(vector.Vector2{}, vector.Vector2{X: 1e6}, vector.Vector2{X: 1e6, Y: 1e-5}), Degenerate},
+		{"line", polygonPath(vector.Vector2{}, vector.Vector2{X: 1, Y: 1}), Degenerate},
+		{"point", polygonPath(vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 1, Y: 1}), Degenerate},
+	}
+	for _, test := range tests {
+		if orientation := test.path.Orientation(); orientation != test.orientation {
+			t.Errorf("Orientation() of the %s = %v, expected %v", test.name, orientation, test.orientation)
+		}
+	}
+
+	// the centroid of a tiny square is its centre, not the origin
+	if centroid := square(1e-6, 1e-6, 1e-6).Centroid(); !approxPoint(centroid, vector.Vector2{X: 1.5e-6, Y: 1.5e-6}, 1e-15) {
+		t.Errorf("Centroid() = %v, expected (1.5e-6, 1.5e-6)", centroid)
+	}
+}
@@ -0,0 +1,99 @@
+package svg
+
+import "encoding/xml"
+
+// XML namespaces
+const (
+	xmlnsNamespace    = "xmlns"
+	inkscapeNamespace = "http://www.inkscape.org/namespaces/inkscape"
+)
+
+// Attributes represents the attributes of an element, named by their namespace URI and local name, in document order
+// attributes with an undeclared prefix keep the prefix as their namespace
+type Attributes []xml.Attr
+
+// newAttributes creates and returns the attributes from the given XML attributes
+// namespace declarations are not considered attributes
+func newAttributes(attrs []xml.Attr) Attributes {
+	attributes := make(Attributes, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Name.Space == xmlnsNamespace || (attr.Name.Space == "" && attr.Name.Local == xmlnsNamespace) {
+			continue
+		}
+		attributes = append(attributes, attr)
+	}
+
+	return attributes
+}
+
+// Get returns the value of the attribute with the given local name, regardless of its namespace
+// an attribute without namespace takes precedence over the namespaced ones, which are matched in document order
+func (a Attributes) Get(name string) (string, bool) {
+	if value, ok := a.GetNS("", name); ok {
+		return value, true
+	}
+	for _, attr := range a {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// GetNS returns the value of the attribute with the given namespace URI and local name
+func (a Attributes) GetNS(space, name string) (string, bool) {
+	for _, attr := range a {
+		if attr.Name.Space == space && attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// set returns the attributes with the given value of the attribute with the given namespace URI and local name,
+// replacing its current value or adding it at the end
+func (a Attributes) set(space, name, value string) Attributes {
+	for i, attr := range a {
+		if attr.Name.Space == space && attr.Name.Local == name {
+			// copies the attributes, since they may be shared
+			a = append(Attributes(nil), a...)
+			a[i].Value = value
+			return a
+		}
+	}
+
+	return append(a[:len(a):len(a)], xml.Attr{Name: xml.Name{Space: space, Local: name}, Value: value})
+}
+
+// attr returns the value of the attribute of the element with the given namespace URI and local name
+func (e element) attr(space, name string) string {
+	for _, attr := range e.Attrs {
+		if attr.Name.Space == space && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// ID returns the identifier of the element
+func (e element) ID() string {
+	return e.attr("", "id")
+}
+
+// Data returns the "d" attribute of the element
+func (e element) Data() string {
+	return e.attr("", "d")
+}
+
+// Label returns the name given to the element by Inkscape
+func (e element) Label() string {
+	return e.attr(inkscapeNamespace, "label")
+}
+
+// GroupMode returns the Inkscape group mode of the element
+func (e element) GroupMode() string {
+	return e.attr(inkscapeNamespace, "groupmode")
+}
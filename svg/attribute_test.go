@@ -0,0 +1,49 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+)
+
+// namespacedDocument has a path with the same local name in two namespaces, before and after the un-namespaced one
+const namespacedDocument = `<svg xmlns:b="urn:b" xmlns:a="urn:a">
+	<path id="p" b:kind="second" a:kind="first" a:weight="2" d="M0 0 L1 1"/>
+	<path id="q" a:kind="first" kind="plain" b:kind="second" d="M0 0 L1 1"/>
+</svg>`
+
+func TestAttributes(t *testing.T) {
+	paths, err := ParsePath([]byte(namespacedDocument), ParserOptions{})
+	if err != nil || len(paths) != 2 {
+		t.Fatalf("ParsePath returned %d paths and the error %v", len(paths), err)
+	}
+
+	tests := []struct {
+		name   string
+		get    func(Attributes) (string, bool)
+		values [2]string
+	}{
+		// the first namespaced attribute in document order, unless there is an un-namespaced one
+		{"Get(kind)", func(a Attributes) (string, bool) { return a.Get("kind") }, [2]string{"second", "plain"}},
+		{"GetNS(urn:a, kind)", func(a Attributes) (string, bool) { return a.GetNS("urn:a", "kind") }, [2]string{"first", "first"}},
+		{"GetNS(, kind)", func(a Attributes) (string, bool) { return a.GetNS("", "kind") }, [2]string{"", "plain"}},
+		{"Get(weight)", func(a Attributes) (string, bool) { return a.Get("weight") }, [2]string{"2", ""}},
+	}
+	for _, test := range tests {
+		for i, path := range paths {
+			// repeated, since the result must not depend on the run
+			for n := 0; n < 20; n++ {
+				value, ok := test.get(path.Attributes)
+				if value != test.values[i] || ok != (test.values[i] != "") {
+					t.Fatalf("%s of %s = (%q, %v), expected %q", test.name, path.ID, value, ok, test.values[i])
+				}
+			}
+		}
+	}
+
+	// namespace declarations are not attributes
+	for _, attr := range paths[0].Attributes {
+		if attr.Name.Space == xmlnsNamespace || strings.HasPrefix(attr.Name.Local, xmlnsNamespace) {
+			t.Errorf("unexpected namespace declaration %v", attr.Name)
+		}
+	}
+}
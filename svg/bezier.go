@@ -0,0 +1,163 @@
+package svg
+
+// For more information on cubic Bézier curves:
+// - https://en.wikipedia.org/wiki/B%C3%A9zier_curve#Cubic_B%C3%A9zier_curves
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// epsilon is the tolerance used to consider lengths and parameters as zero
+const epsilon = 1e-9
+
+// tangentOffset is the parameter offset used to find the tangent of a curve at a degenerate point
+const tangentOffset = 1e-4
+
+// Point returns the position of the curve at t, where t is in the range [0, 1]
+func (p PathData) Point(t float64) vector.Vector2 {
+	mt := 1 - t
+	a := mt * mt * mt
+	b := 3 * mt * mt * t
+	c := 3 * mt * t * t
+	d := t * t * t
+
+	return vector.Vector2{
+		X: a*p.Start.X + b*p.Control[0].X + c*p.Control[1].X + d*p.End.X,
+		Y: a*p.Start.Y + b*p.Control[0].Y + c*p.Control[1].Y + d*p.End.Y,
+	}
+}
+
+// Derivative returns the first derivative of the curve at t
+func (p PathData) Derivative(t float64) vector.Vector2 {
+	mt := 1 - t
+	a := p.Control[0].Sub(p.Start).Mul(3 * mt * mt)
+	b := p.Control[1].Sub(p.Control[0]).Mul(6 * mt * t)
+	c := p.End.Sub(p.Control[1]).Mul(3 * t * t)
+
+	return a.Add(b).Add(c)
+}
+
+// SecondDerivative returns the second derivative of the curve at t
+func (p PathData) SecondDerivative(t float64) vector.Vector2 {
+	a := p.Control[1].Sub(p.Control[0].Mul(2)).Add(p.Start).Mul(6 * (1 - t))
+	b := p.End.Sub(p.Control[1].Mul(2)).Add(p.Control[0]).Mul(6 * t)
+
+	return a.Add(b)
+}
+
+// Tangent returns the unit tangent of the curve at t
+// where the derivative vanishes (e.g. a control point on top of an endpoint), the direction of the nearby curve is used
+func (p PathData) Tangent(t float64) vector.Vector2 {
+	derivative := p.Derivative(t)
+	if derivative.Magnitude() < epsilon {
+		// looking at the curve slightly further inside
+		if t < 0.5 {
+			derivative = p.Point(t + tangentOffset).Sub(p.Point(t))
+		} else {
+			derivative = p.Point(t).Sub(p.Point(t - tangentOffset))
+		}
+	}
+	if derivative.Magnitude() < epsilon {
+		// the curve is degenerate, so the chord is the best guess
+		derivative = p.End.Sub(p.Start)
+	}
+	if derivative.Magnitude() < epsilon {
+		return vector.Vector2{}
+	}
+
+	return derivative.Normalized()
+}
+
+// Normal returns the unit normal of the curve at t, which is the unit tangent rotated by 90º (see Vector2.Left)
+func (p PathData) Normal(t float64) vector.Vector2 {
+	return p.Tangent(t).Left()
+}
+
+// Curvature returns the signed curvature of the curve at t
+// it is positive when the curve turns towards its normal, and zero where the curve is degenerate
+func (p PathData) Curvature(t float64) float64 {
+	return curvature(p.Derivative(t), p.SecondDerivative(t))
+}
+
+// curvature returns the signed curvature given the first and second derivatives
+func curvature(first, second vector.Vector2) float64 {
+	speed := first.Magnitude()
+	if speed < epsilon {
+		return 0
+	}
+
+	return first.Cross(second) / (speed * speed * speed)
+}
+
+// Locate converts a global parameter t of the path, in the range [0, 1], into the index of the segment
+// (path data) and its local parameter, where every segment spans the same parameter range
+func (p Path) Locate(t float64) (int, float64) {
+	if len(p.Data) == 0 {
+		return -1, 0
+	}
+
+	scaled := mathf.Clamp(t, 0, 1) * float64(len(p.Data))
+	index := int(math.Min(math.Floor(scaled), float64(len(p.Data)-1)))
+
+	return index, scaled - float64(index)
+}
+
+// Point returns the position of the path at the global parameter t, where t is in the range [0, 1]
+func (p Path) Point(t float64) vector.Vector2 {
+	index, local := p.Locate(t)
+	if index < 0 {
+		return vector.Vector2{}
+	}
+
+	return p.Data[index].Point(local)
+}
+
+// Derivative returns the first derivative of the path with respect to the global parameter t
+func (p Path) Derivative(t float64) vector.Vector2 {
+	index, local := p.Locate(t)
+	if index < 0 {
+		return vector.Vector2{}
+	}
+
+	// every segment spans 1/n of the global parameter
+	return p.Data[index].Derivative(local).Mul(float64(len(p.Data)))
+}
+
+// SecondDerivative returns the second derivative of the path with respect to the global parameter t
+func (p Path) SecondDerivative(t float64) vector.Vector2 {
+	index, local := p.Locate(t)
+	if index < 0 {
+		return vector.Vector2{}
+	}
+
+	n := float64(len(p.Data))
+	return p.Data[index].SecondDerivative(local).Mul(n * n)
+}
+
+// Tangent returns the unit tangent of the path at the global parameter t
+func (p Path) Tangent(t float64) vector.Vector2 {
+	index, local := p.Locate(t)
+	if index < 0 {
+		return vector.Vector2{}
+	}
+
+	return p.Data[index].Tangent(local)
+}
+
+// Normal returns the unit normal of the path at the global parameter t
+func (p Path) Normal(t float64) vector.Vector2 {
+	return p.Tangent(t).Left()
+}
+
+// Curvature returns the signed curvature of the path at the global parameter t
+func (p Path) Curvature(t float64) float64 {
+	index, local := p.Locate(t)
+	if index < 0 {
+		return 0
+	}
+
+	return p.Data[index].Curvature(local)
+}
@@ -0,0 +1,343 @@
+package svg
+
+// For more information on boolean operations on polygons:
+// - https://en.wikipedia.org/wiki/Boolean_operations_on_polygons
+
+import (
+	"math"
+	"sort"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// BooleanOperation represents an operation combining the areas enclosed by two paths
+type BooleanOperation int
+
+const (
+	// UnionOperation keeps the area inside either path
+	UnionOperation BooleanOperation = iota
+	// IntersectionOperation keeps the area inside both paths
+	IntersectionOperation
+	// DifferenceOperation keeps the area inside the first path but outside the second one
+	DifferenceOperation
+	// XorOperation keeps the area inside exactly one of the paths
+	XorOperation
+)
+
+// boolean operation settings
+const (
+	// distance, relative to the size of the operands, below which two vertices are welded
+	weldPrecision = 1e-9
+	// distance, relative to the size of the operands, at which both sides of an edge are sampled
+	probePrecision = 1e-6
+)
+
+// apply returns whether a point is inside the result, given whether it is inside each operand
+func (o BooleanOperation) apply(a, b bool) bool {
+	switch o {
+	case IntersectionOperation:
+		return a && b
+	case DifferenceOperation:
+		return a && !b
+	case XorOperation:
+		return a != b
+	}
+	return a || b
+}
+
+// edge represents a straight edge of a polygon
+type edge struct {
+	start, end vector.Vector2
+}
+
+// polygon represents the flattened area enclosed by a path, filled with a fill rule
+type polygon struct {
+	edges []edge
+	rule  FillRule
+}
+
+// newPolygon flattens the path within the given tolerance, implicitly closing its open subpaths
+func newPolygon(p Path, rule FillRule, tolerance float64) polygon {
+	poly := polygon{rule: rule}
+	for _, polyline := range p.Polylines(tolerance) {
+		poly.edges = appendPolyline(poly.edges, polyline)
+		if last := polyline[len(polyline)-1]; last != polyline[0] {
+			poly.edges = append(poly.edges, edge{start: last, end: polyline[0]})
+		}
+	}
+
+	return poly
+}
+
+// appendPolyline appends the edges of the polyline, skipping the ones without length
+func appendPolyline(edges []edge, polyline []vector.Vector2) []edge {
+	for i := 1; i < len(polyline); i++ {
+		if polyline[i] != polyline[i-1] {
+			edges = append(edges, edge{start: polyline[i-1], end: polyline[i]})
+		}
+	}
+	return edges
+}
+
+// contains checks if the point is inside the polygon, according to its fill rule
+func (p polygon) contains(point vector.Vector2) bool {
+	var winding int
+	for _, e := range p.edges {
+		// half-open rule: upward edges include their start and downward edges include their end
+		side := e.end.Sub(e.start).Cross(point.Sub(e.start))
+		if e.start.Y <= point.Y {
+			if e.end.Y > point.Y && side > 0 {
+				winding++
+			}
+		} else if e.end.Y <= point.Y && side < 0 {
+			winding--
+		}
+	}
+
+	if p.rule == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// Boolean combines the areas enclosed by this path and the other one, according to the operation and to the fill rule
+// of each path, returning a copy of this path whose data contains the resulting contours
+// the curves are flattened within the given tolerance (zero or less uses the default, 0.1), so the contours are made
+// of straight lines; outer contours are counter-clockwise and holes clockwise (in a y-up coordinate system),
+// so the result is filled alike with either fill rule
+func (p Path) Boolean(other Path, operation BooleanOperation, tolerance float64) Path {
+	a, b := newPolygon(p, p.FillRule(), tolerance), newPolygon(other, other.FillRule(), tolerance)
+	return p.combine(a, b, operation, Bounds([]Path{p, other}).Size().Magnitude())
+}
+
+// combine combines the areas of two polygons, of the given size, returning a copy of this path with the resulting contours
+func (p Path) combine(a, b polygon, operation BooleanOperation, scale float64) Path {
+	result := p
+	result.Data = nil
+	for _, contour := range combineContours(a, b, operation, scale) {
+		for i, point := range contour {
+			result.Data = append(result.Data, newLine(point, contour[(i+1)%len(contour)]))
+		}
+	}
+
+	return result
+}
+
+// combineContours combines the areas of two polygons, of the given size, returning the vertices of the resulting contours
+func combineContours(a, b polygon, operation BooleanOperation, scale float64) [][]vector.Vector2 {
+	weld := math.Max(weldPrecision*scale, epsilon)
+	probe := math.Max(probePrecision*scale, epsilon)
+
+	edges := splitEdges(append(append([]edge(nil), a.edges...), b.edges...), weld)
+
+	// keeps the edges between the inside and the outside of the result, with the inside on their left
+	var kept []edge
+	seen := make(map[edge]bool)
+	for _, e := range edges {
+		direction := e.end.Sub(e.start).Normalized()
+		middle := vector.LerpUnclamped(e.start, e.end, 0.5)
+		left := middle.Add(direction.Left().Mul(probe))
+		right := middle.Sub(direction.Left().Mul(probe))
+
+		insideLeft := operation.apply(a.contains(left), b.contains(left))
+		insideRight := operation.apply(a.contains(right), b.contains(right))
+		if insideLeft == insideRight {
+			continue
+		}
+		if insideRight {
+			e = edge{start: e.end, end: e.start}
+		}
+		// coincident edges of both operands are kept once
+		if !seen[e] {
+			seen[e] = true
+			kept = append(kept, e)
+		}
+	}
+
+	var contours [][]vector.Vector2
+	for _, contour := range linkEdges(kept) {
+		// contours without area vanish once their collinear vertices are merged
+		if contour = mergeCollinear(contour, weld); len(contour) >= 3 {
+			contours = append(contours, contour)
+		}
+	}
+
+	return contours
+}
+
+// splitPoint represents a point where an edge is split
+type splitPoint struct {
+	fraction float64
+	point    vector.Vector2
+}
+
+// splitEdges splits the edges wherever they intersect or touch each other, welding the vertices closer than
+// the given distance, and returns the resulting edges
+func splitEdges(edges []edge, weld float64) []edge {
+	boxes := make([]Box, len(edges))
+	order := make([]int, len(edges))
+	for i, e := range edges {
+		boxes[i] = emptyBox().Extend(e.start).Extend(e.end)
+		order[i] = i
+	}
+	sort.Slice(order, func(m, n int) bool { return boxes[order[m]].Min.X < boxes[order[n]].Min.X })
+
+	splits := make([][]splitPoint, len(edges))
+	for k, i := range order {
+		for _, j := range order[k+1:] {
+			// the edges are sorted by their left side, so the remaining ones cannot overlap
+			if boxes[j].Min.X > boxes[i].Max.X+weld {
+				break
+			}
+			if !boxes[i].overlaps(boxes[j], weld) {
+				continue
+			}
+
+			a, b := edges[i], edges[j]
+			if u, v, ok := segmentIntersection(a.start, a.end, b.start, b.end); ok {
+				point := intersectionPoint(a, u, b, v)
+				splits[i] = append(splits[i], splitPoint{u, point})
+				splits[j] = append(splits[j], splitPoint{v, point})
+				continue
+			}
+
+			// otherwise (e.g. parallel edges), they touch or overlap where an endpoint of one lies on the other
+			splits[i] = appendTouching(splits[i], a, b, weld)
+			splits[j] = appendTouching(splits[j], b, a, weld)
+		}
+	}
+
+	var split []edge
+	vertices := newVertexWelder(weld)
+	for i, e := range edges {
+		points := append(splits[i], splitPoint{0, e.start}, splitPoint{1, e.end})
+		sort.Slice(points, func(m, n int) bool { return points[m].fraction < points[n].fraction })
+
+		for k := 1; k < len(points); k++ {
+			start, end := vertices.weld(points[k-1].point), vertices.weld(points[k].point)
+			if start != end {
+				split = append(split, edge{start: start, end: end})
+			}
+		}
+	}
+
+	return split
+}
+
+// intersectionPoint returns the intersection point of two edges, preferring their endpoints to avoid rounding errors
+func intersectionPoint(a edge, u float64, b edge, v float64) vector.Vector2 {
+	switch {
+	case v == 0:
+		return b.start
+	case v == 1:
+		return b.end
+	case u == 0:
+		return a.start
+	case u == 1:
+		return a.end
+	}
+	return vector.LerpUnclamped(a.start, a.end, u)
+}
+
+// appendTouching appends the endpoints of the other edge lying on the edge to its split points
+func appendTouching(splits []splitPoint, e, other edge, weld float64) []splitPoint {
+	direction := e.end.Sub(e.start)
+	length := direction.MagnitudeSqr()
+	for _, point := range [...]vector.Vector2{other.start, other.end} {
+		if distanceToSegment(point, e.start, e.end) <= weld {
+			splits = append(splits, splitPoint{point.Sub(e.start).Dot(direction) / length, point})
+		}
+	}
+	return splits
+}
+
+// vertexWelder replaces the points closer than a given distance by the same vertex
+type vertexWelder struct {
+	distance float64
+	vertices map[[2]int64]vector.Vector2
+}
+
+// newVertexWelder creates and returns a vertex welder for the given distance
+func newVertexWelder(distance float64) vertexWelder {
+	return vertexWelder{distance: distance, vertices: make(map[[2]int64]vector.Vector2)}
+}
+
+// weld returns the vertex replacing the given point
+func (w vertexWelder) weld(point vector.Vector2) vector.Vector2 {
+	key := [2]int64{int64(math.Round(point.X / w.distance)), int64(math.Round(point.Y / w.distance))}
+	if vertex, ok := w.vertices[key]; ok {
+		return vertex
+	}
+
+	w.vertices[key] = point
+	return point
+}
+
+// linkEdges links the directed edges into closed contours, returned as their vertices
+// at vertices shared by several contours, the sharpest turn to the left is taken, keeping the contours separate;
+// chains of edges that do not return to their start (left by rounding errors) are discarded
+func linkEdges(edges []edge) [][]vector.Vector2 {
+	outgoing := make(map[vector.Vector2][]int)
+	for i, e := range edges {
+		outgoing[e.start] = append(outgoing[e.start], i)
+	}
+
+	var contours [][]vector.Vector2
+	used := make([]bool, len(edges))
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+
+		var contour []vector.Vector2
+		closed := false
+		for current := i; current >= 0; {
+			used[current] = true
+			e := edges[current]
+			contour = append(contour, e.start)
+			if e.end == edges[i].start {
+				closed = true
+				break
+			}
+
+			// the next edge is the first one found turning clockwise from the way back
+			back := math.Atan2(e.start.Y-e.end.Y, e.start.X-e.end.X)
+			current = -1
+			best := math.Inf(1)
+			for _, j := range outgoing[e.end] {
+				if used[j] {
+					continue
+				}
+				angle := back - math.Atan2(edges[j].end.Y-edges[j].start.Y, edges[j].end.X-edges[j].start.X)
+				for angle <= 0 {
+					angle += 2 * math.Pi
+				}
+				if angle < best {
+					current, best = j, angle
+				}
+			}
+		}
+
+		if closed && len(contour) >= 3 {
+			contours = append(contours, contour)
+		}
+	}
+
+	return contours
+}
+
+// mergeCollinear removes the vertices of the closed contour lying on the line between their neighbours
+func mergeCollinear(contour []vector.Vector2, distance float64) []vector.Vector2 {
+	merged := append([]vector.Vector2(nil), contour...)
+	for i := 0; i < len(merged) && len(merged) > 2; {
+		previous := merged[(i+len(merged)-1)%len(merged)]
+		next := merged[(i+1)%len(merged)]
+		if distanceToSegment(merged[i], previous, next) <= distance {
+			merged = append(merged[:i], merged[i+1:]...)
+			continue
+		}
+		i++
+	}
+
+	return merged
+}
@@ -0,0 +1,79 @@
+package svg
+
+import (
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// polygonPath returns a closed path made of straight lines through the given points
+func polygonPath(points ...vector.Vector2) Path {
+	var path Path
+	for i, point := range points {
+		path.Data = append(path.Data, newLine(point, points[(i+1)%len(points)]))
+	}
+	return path
+}
+
+// square returns the counter-clockwise square with the given corner and size
+func square(x, y, size float64) Path {
+	return polygonPath(
+		vector.Vector2{X: x, Y: y}, vector.Vector2{X: x + size, Y: y},
+		vector.Vector2{X: x + size, Y: y + size}, vector.Vector2{X: x, Y: y + size},
+	)
+}
+
+func TestBoolean(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      Path
+		operation BooleanOperation
+		area      float64
+	}{
+		{"union", square(0, 0, 2), square(1, 1, 2), UnionOperation, 7},
+		{"intersection", square(0, 0, 2), square(1, 1, 2), IntersectionOperation, 1},
+		{"difference", square(0, 0, 2), square(1, 1, 2), DifferenceOperation, 3},
+		{"xor", square(0, 0, 2), square(1, 1, 2), XorOperation, 6},
+		// the direction of the contours does not matter with the nonzero fill rule
+		{"clockwise union", square(0, 0, 2).Reverse(), square(1, 1, 2), UnionOperation, 7},
+		{"clockwise difference", square(0, 0, 2), square(1, 1, 2).Reverse(), DifferenceOperation, 3},
+		{"disjoint union", square(0, 0, 2), square(5, 5, 1), UnionOperation, 5},
+		{"disjoint intersection", square(0, 0, 2), square(5, 5, 1), IntersectionOperation, 0},
+		{"shared edge union", square(0, 0, 2), square(2, 0, 2), UnionOperation, 8},
+		{"contained difference", square(0, 0, 4), square(1, 1, 2), DifferenceOperation, 12},
+		{"identical xor", square(0, 0, 2), square(0, 0, 2), XorOperation, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.a.Boolean(test.b, test.operation, 0)
+			// the holes are clockwise, so the signed area is the filled one
+			if area := result.Area(); !approxEqual(area, test.area, 1e-9) {
+				t.Errorf("Area() = %v, expected %v", area, test.area)
+			}
+			for _, contour := range result.Subpaths() {
+				if !contour.IsClosed() {
+					t.Errorf("the contour %v is not closed", contour.Data)
+				}
+			}
+		})
+	}
+}
+
+func TestLinkEdges(t *testing.T) {
+	corners := []vector.Vector2{{}, {X: 1}, {X: 1, Y: 1}, {Y: 1}}
+	var edges []edge
+	for i, corner := range corners {
+		edges = append(edges, edge{corner, corners[(i+1)%len(corners)]})
+	}
+	// a chain left open by rounding errors, which never returns to its start
+	edges = append(edges,
+		edge{vector.Vector2{X: 5}, vector.Vector2{X: 6}},
+		edge{vector.Vector2{X: 6}, vector.Vector2{X: 6, Y: 1}},
+		edge{vector.Vector2{X: 6, Y: 1}, vector.Vector2{X: 5, Y: 1.001}},
+	)
+
+	contours := linkEdges(edges)
+	if len(contours) != 1 || len(contours[0]) != len(corners) {
+		t.Fatalf("linkEdges returned %v, expected the square only", contours)
+	}
+}
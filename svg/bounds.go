@@ -0,0 +1,128 @@
+package svg
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Box represents an axis-aligned bounding box
+type Box struct {
+	Min, Max vector.Vector2
+}
+
+// emptyBox returns a box that contains nothing, which is the identity of the union
+func emptyBox() Box {
+	return Box{
+		Min: vector.Vector2{X: math.Inf(1), Y: math.Inf(1)},
+		Max: vector.Vector2{X: math.Inf(-1), Y: math.Inf(-1)},
+	}
+}
+
+// IsEmpty checks if the box contains nothing
+func (b Box) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y
+}
+
+// Size returns the width and height of the box
+func (b Box) Size() vector.Vector2 {
+	if b.IsEmpty() {
+		return vector.Vector2{}
+	}
+	return b.Max.Sub(b.Min)
+}
+
+// Center returns the center point of the box
+func (b Box) Center() vector.Vector2 {
+	return vector.Vector2{X: 0.5 * (b.Min.X + b.Max.X), Y: 0.5 * (b.Min.Y + b.Max.Y)}
+}
+
+// Union returns the smallest box containing both boxes
+func (b Box) Union(other Box) Box {
+	return Box{
+		Min: vector.Vector2{X: math.Min(b.Min.X, other.Min.X), Y: math.Min(b.Min.Y, other.Min.Y)},
+		Max: vector.Vector2{X: math.Max(b.Max.X, other.Max.X), Y: math.Max(b.Max.Y, other.Max.Y)},
+	}
+}
+
+// Extend returns the smallest box containing both the box and the given point
+func (b Box) Extend(point vector.Vector2) Box {
+	return b.Union(Box{Min: point, Max: point})
+}
+
+// Bounds returns the tight bounding box of the path data
+// besides the endpoints, the curve can only reach its extremes where its derivative is zero
+func (p PathData) Bounds() Box {
+	box := emptyBox().Extend(p.Start).Extend(p.End)
+	for _, t := range p.extremes() {
+		box = box.Extend(p.Point(t))
+	}
+
+	return box
+}
+
+// extremes returns the parameters, inside the range (0, 1), where either axis of the curve has a zero derivative
+func (p PathData) extremes() []float64 {
+	// the derivative of each axis is the quadratic 3*(a*t^2 + b*t + c), with:
+	//   a = -p0 + 3*p1 - 3*p2 + p3
+	//   b = 2*(p0 - 2*p1 + p2)
+	//   c = p1 - p0
+	axis := func(p0, p1, p2, p3 float64) []float64 {
+		return unitRoots(solveQuadratic(-p0+3*p1-3*p2+p3, 2*(p0-2*p1+p2), p1-p0))
+	}
+
+	return append(
+		axis(p.Start.X, p.Control[0].X, p.Control[1].X, p.End.X),
+		axis(p.Start.Y, p.Control[0].Y, p.Control[1].Y, p.End.Y)...,
+	)
+}
+
+// TransformedBounds returns the tight bounding box of the path data under the given transformation
+func (p PathData) TransformedBounds(t Transform) Box {
+	return p.Transform(t).Bounds()
+}
+
+// Bounds returns the tight bounding box of the path
+func (p Path) Bounds() Box {
+	return p.TransformedBounds(Identity())
+}
+
+// TransformedBounds returns the tight bounding box of the path under the given transformation
+func (p Path) TransformedBounds(t Transform) Box {
+	box := emptyBox()
+	for _, data := range p.Data {
+		box = box.Union(data.TransformedBounds(t))
+	}
+
+	return box
+}
+
+// Bounds returns the tight bounding box of every path in the group, including the nested ones
+func (g Group) Bounds() Box {
+	return g.TransformedBounds(Identity())
+}
+
+// TransformedBounds returns the tight bounding box of every path in the group under the given transformation
+func (g Group) TransformedBounds(t Transform) Box {
+	box := TransformedBounds(g.Paths, t)
+	for _, group := range g.Groups {
+		box = box.Union(group.TransformedBounds(t))
+	}
+
+	return box
+}
+
+// Bounds returns the tight bounding box of all the given paths, such as the ones of a document
+func Bounds(paths []Path) Box {
+	return TransformedBounds(paths, Identity())
+}
+
+// TransformedBounds returns the tight bounding box of all the given paths under the given transformation
+func TransformedBounds(paths []Path, t Transform) Box {
+	box := emptyBox()
+	for _, path := range paths {
+		box = box.Union(path.TransformedBounds(t))
+	}
+
+	return box
+}
@@ -0,0 +1,84 @@
+package svg
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// CleanupPathData returns the path data without the segments that are not needed, within the distance tolerance
+// (zero or less only cleans up the exact cases):
+// - the cubics whose control points are no further than the tolerance from their chord are demoted to straight lines
+// - the segments shorter than the tolerance are removed, unless they are a whole subpath, such as a dot
+// - the consecutive straight lines are merged while their inner points are no further than the tolerance from the merged line
+func CleanupPathData(data []PathData, tolerance float64) []PathData {
+	tolerance = math.Max(tolerance, epsilon)
+	// the start of a segment may be moved when the previous one is removed
+	data = append([]PathData(nil), data...)
+
+	var cleaned []PathData
+	// inner points of the last straight line, checked again every time another line is merged into it
+	var inner []vector.Vector2
+	for i, d := range data {
+		// the control points bound the curve, so it is no further than the tolerance from its chord
+		if !d.IsLine() && d.flatness() < tolerance {
+			d = newLine(d.Start, d.End)
+		}
+
+		connected := len(cleaned) > 0 && cleaned[len(cleaned)-1].End == d.Start
+		if isDegenerate(d, tolerance) {
+			// the segments around it are kept connected, the previous one ending where it ends
+			if last := len(cleaned) - 1; connected {
+				if cleaned[last].IsLine() {
+					cleaned[last] = newLine(cleaned[last].Start, d.End)
+				} else {
+					cleaned[last].End = d.End
+				}
+				continue
+			}
+			// or, at the start of a subpath, the next one starting where it starts
+			if i+1 < len(data) && data[i+1].Start == d.End {
+				data[i+1].Start = d.Start
+				continue
+			}
+		}
+
+		if previous := len(cleaned) - 1; connected && d.IsLine() && cleaned[previous].IsLine() {
+			merged := newLine(cleaned[previous].Start, d.End)
+			if canMerge(merged, append(inner, d.Start), tolerance) {
+				cleaned[previous] = merged
+				inner = append(inner, d.Start)
+				continue
+			}
+		}
+
+		cleaned = append(cleaned, d)
+		inner = nil
+	}
+
+	return cleaned
+}
+
+// Cleanup returns a copy of the path without the segments that are not needed (see CleanupPathData)
+func (p Path) Cleanup(tolerance float64) Path {
+	p.Data = CleanupPathData(p.Data, tolerance)
+	return p
+}
+
+// isDegenerate checks if every point of the segment is no further than the tolerance from its start
+func isDegenerate(d PathData, tolerance float64) bool {
+	return d.Start.Distance(d.End) < tolerance &&
+		d.Start.Distance(d.Control[0]) < tolerance &&
+		d.Start.Distance(d.Control[1]) < tolerance
+}
+
+// canMerge checks if the points replaced by the straight line are no further than the tolerance from it
+func canMerge(line PathData, points []vector.Vector2, tolerance float64) bool {
+	for _, point := range points {
+		if distanceToSegment(point, line.Start, line.End) >= tolerance {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package svg
+
+import (
+	"reflect"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// polyline returns the straight lines between the points
+func polyline(points ...vector.Vector2) []PathData {
+	var data []PathData
+	for i := 1; i < len(points); i++ {
+		data = append(data, newLine(points[i-1], points[i]))
+	}
+	return data
+}
+
+func TestCleanupPathData(t *testing.T) {
+	flat := PathData{Start: vector.Vector2{}, Control: [2]vector.Vector2{{X: 3, Y: 0.01}, {X: 6, Y: -0.01}}, End: vector.Vector2{X: 9}}
+	curved := quarterCircle(vector.Vector2{}, 10, 0)
+
+	tests := []struct {
+		name      string
+		data      []PathData
+		tolerance float64
+		cleaned   []PathData
+	}{
+		{"flat cubic", []PathData{flat}, 0.1, polyline(vector.Vector2{}, vector.Vector2{X: 9})},
+		{"cubic beyond the tolerance", []PathData{flat}, 0.001, []PathData{flat}},
+		{"curve", []PathData{curved}, 0.1, []PathData{curved}},
+		// the demoted cubic is merged with the line after it
+		{"flat cubic and line", append([]PathData{flat}, newLine(vector.Vector2{X: 9}, vector.Vector2{X: 12})), 0.1,
+			polyline(vector.Vector2{}, vector.Vector2{X: 12})},
+		{"collinear lines", polyline(vector.Vector2{}, vector.Vector2{X: 1}, vector.Vector2{X: 2}, vector.Vector2{X: 5}), 0,
+			polyline(vector.Vector2{}, vector.Vector2{X: 5})},
+		{"nearly collinear lines", polyline(vector.Vector2{}, vector.Vector2{X: 5, Y: 0.05}, vector.Vector2{X: 10}), 0.1,
+			polyline(vector.Vector2{}, vector.Vector2{X: 10})},
+		{"lines beyond the tolerance", polyline(vector.Vector2{}, vector.Vector2{X: 5, Y: 0.05}, vector.Vector2{X: 10}), 0.01,
+			polyline(vector.Vector2{}, vector.Vector2{X: 5, Y: 0.05}, vector.Vector2{X: 10})},
+		// a line doubling back is not collinear with the previous one, as far as the lines go
+		{"spike", polyline(vector.Vector2{}, vector.Vector2{X: 5}, vector.Vector2{X: 2}), 0.1,
+			polyline(vector.Vector2{}, vector.Vector2{X: 5}, vector.Vector2{X: 2})},
+		// the previous line ends where the short one ends
+		{"short segment", polyline(vector.Vector2{}, vector.Vector2{X: 5}, vector.Vector2{X: 5.05}, vector.Vector2{X: 5.05, Y: 5}), 0.1,
+			polyline(vector.Vector2{}, vector.Vector2{X: 5.05}, vector.Vector2{X: 5.05, Y: 5})},
+		// at the start of a subpath, the next segment starts where the short one starts
+		{"short first segment", polyline(vector.Vector2{}, vector.Vector2{Y: 0.05}, vector.Vector2{X: 5, Y: 5}), 0.1,
+			polyline(vector.Vector2{}, vector.Vector2{X: 5, Y: 5})},
+		{"dot", append(polyline(vector.Vector2{}, vector.Vector2{X: 5}), newLine(vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 1, Y: 1})), 0.1,
+			append(polyline(vector.Vector2{}, vector.Vector2{X: 5}), newLine(vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 1, Y: 1}))},
+		// lines of separate subpaths are not merged
+		{"subpaths", append(polyline(vector.Vector2{}, vector.Vector2{X: 5}), newLine(vector.Vector2{X: 6}, vector.Vector2{X: 7})), 0.1,
+			append(polyline(vector.Vector2{}, vector.Vector2{X: 5}), newLine(vector.Vector2{X: 6}, vector.Vector2{X: 7}))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := append([]PathData(nil), test.data...)
+			if cleaned := CleanupPathData(test.data, test.tolerance); !reflect.DeepEqual(cleaned, test.cleaned) {
+				t.Errorf("CleanupPathData() = %v, expected %v", cleaned, test.cleaned)
+			}
+			if !reflect.DeepEqual(test.data, original) {
+				t.Errorf("the path data was modified into %v", test.data)
+			}
+		})
+	}
+}
+
+func TestCleanupDrift(t *testing.T) {
+	// a gentle curve, whose points drift away from the line between its ends little by little
+	var points []vector.Vector2
+	for i := 0; i <= 20; i++ {
+		x := float64(i)
+		points = append(points, vector.Vector2{X: x, Y: 0.01 * x * x})
+	}
+	path := Path{Data: polyline(points...)}
+
+	// merging a line checks every point it replaces, not only the last one
+	cleaned := path.Cleanup(0.1)
+	if len(cleaned.Data) < 2 || len(cleaned.Data) >= len(path.Data) {
+		t.Fatalf("got %d lines from %d, expected some to be merged", len(cleaned.Data), len(path.Data))
+	}
+	for _, point := range points {
+		if distance := cleaned.Nearest(point).Distance; distance >= 0.1 {
+			t.Errorf("the point %v is %v away from the cleaned path", point, distance)
+		}
+	}
+	if cleaned.Data[0].Start != points[0] || cleaned.Data[len(cleaned.Data)-1].End != points[len(points)-1] {
+		t.Errorf("the cleaned path goes from %v to %v", cleaned.Data[0].Start, cleaned.Data[len(cleaned.Data)-1].End)
+	}
+}
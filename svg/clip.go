@@ -0,0 +1,305 @@
+package svg
+
+// For more information on clipping paths:
+// - https://developer.mozilla.org/en-US/docs/Web/SVG/Element/clipPath
+
+import (
+	"sort"
+	"strings"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// clipping tags, properties and values
+const (
+	clipPathElementTag     = "clipPath"
+	clipPathProperty       = "clip-path"
+	clipRuleProperty       = "clip-rule"
+	clipPathUnitsAttribute = "clipPathUnits"
+	transformAttribute     = "transform"
+	objectBoundingBoxValue = "objectBoundingBox"
+	fillProperty           = "fill"
+	noneValue              = "none"
+	urlPrefix              = "url("
+	urlSuffix              = ")"
+	fragmentPrefix         = "#"
+)
+
+// tolerance of the flattening of clipping paths in fractions of the bounding box, relative to the size of the box
+const boundingBoxFlatteningTolerance = 1e-3
+
+// ClipRule returns the clip rule of the path, as defined by its "clip-rule" property
+// defaults to NonZero, as in SVG
+func (p Path) ClipRule() FillRule {
+	if value, ok := p.Attributes.Property(clipRuleProperty); ok && value == evenOddValue {
+		return EvenOdd
+	}
+	return NonZero
+}
+
+// ApplyClip returns a copy of the path intersected with every one of its clipping paths, without clipping paths
+// filled paths (closed and whose fill is not "none") are intersected as areas (see Boolean), while the others,
+// like open strokes, are cut where they cross the clipping paths; either way, the curves are flattened
+// within the given tolerance (zero or less uses the default, 0.1)
+func (p Path) ApplyClip(tolerance float64) Path {
+	clipped := p
+	clipped.Clip = nil
+	filled := p.isFilled()
+	for _, clip := range p.Clip {
+		region := newPolygon(clip, clip.ClipRule(), tolerance)
+		if filled {
+			shape := newPolygon(clipped, clipped.FillRule(), tolerance)
+			clipped = clipped.combine(shape, region, IntersectionOperation, Bounds([]Path{clipped, clip}).Size().Magnitude())
+		} else {
+			clipped = clipped.clipCurves(region, tolerance)
+		}
+	}
+
+	return clipped
+}
+
+// applyClip intersects the path with its clipping paths (see ApplyClip) within the segment limit
+// the path is flattened first, counting its lines instead of its segments, so that the clipping only works on
+// a bounded number of lines; its clipping paths were already flattened along with their content (see clipContent)
+// only the lines added by the clipping are counted, since the ones of the path were already counted
+func (s *parserState) applyClip(p Path) (Path, error) {
+	var err error
+	if p.Data, err = s.reflatten(p.Data, s.Options.FlatteningTolerance); err != nil {
+		return Path{}, err
+	}
+
+	clipped := p.ApplyClip(s.Options.FlatteningTolerance)
+	if added := len(clipped.Data) - len(p.Data); added > 0 {
+		if err := s.countSegments(added); err != nil {
+			return Path{}, err
+		}
+	}
+
+	return clipped, nil
+}
+
+// isFilled checks if the path encloses an area, i.e. it has data, its subpaths are closed and its fill is not "none"
+func (p Path) isFilled() bool {
+	if value, ok := p.Attributes.Property(fillProperty); ok && value == noneValue {
+		return false
+	}
+	for _, subpath := range p.Subpaths() {
+		if !subpath.IsClosed() {
+			return false
+		}
+	}
+	return len(p.Data) > 0
+}
+
+// clipCurves returns a copy of the path, flattened, keeping only the parts inside the region
+func (p Path) clipCurves(region polygon, tolerance float64) Path {
+	clipped := p
+	clipped.Data = nil
+	for _, polyline := range p.Polylines(tolerance) {
+		for _, e := range appendPolyline(nil, polyline) {
+			// splits the edge wherever it crosses the region
+			fractions := []float64{0, 1}
+			box := emptyBox().Extend(e.start).Extend(e.end)
+			for _, other := range region.edges {
+				if !box.overlaps(emptyBox().Extend(other.start).Extend(other.end), 0) {
+					continue
+				}
+				if u, _, ok := segmentIntersection(e.start, e.end, other.start, other.end); ok {
+					fractions = append(fractions, u)
+				}
+			}
+			sort.Float64s(fractions)
+
+			points := make([]vector.Vector2, len(fractions))
+			for i, fraction := range fractions {
+				points[i] = vector.LerpUnclamped(e.start, e.end, fraction)
+			}
+			for i := 1; i < len(points); i++ {
+				if points[i] != points[i-1] && region.contains(vector.LerpUnclamped(points[i-1], points[i], 0.5)) {
+					clipped.Data = append(clipped.Data, newLine(points[i-1], points[i]))
+				}
+			}
+		}
+	}
+
+	return clipped
+}
+
+// clipReference returns the identifier of the clipping path referenced by the "clip-path" property (e.g. "url(#c)")
+func clipReference(attributes Attributes) (string, bool) {
+	value, ok := attributes.Property(clipPathProperty)
+	if !ok || !strings.HasPrefix(value, urlPrefix) || !strings.HasSuffix(value, urlSuffix) {
+		return "", false
+	}
+
+	reference := strings.Trim(strings.TrimSpace(value[len(urlPrefix):len(value)-len(urlSuffix)]), `"'`)
+	if !strings.HasPrefix(reference, fragmentPrefix) {
+		return "", false
+	}
+
+	return reference[len(fragmentPrefix):], true
+}
+
+// collectClipPaths indexes the clipping path definitions found anywhere in the elements, by identifier
+func collectClipPaths(elements []element, definitions map[string]element) {
+	for _, e := range elements {
+		if e.XMLName.Local == clipPathElementTag {
+			if id := e.ID(); id != "" {
+				definitions[id] = e
+			}
+		}
+		collectClipPaths(e.Elements, definitions)
+	}
+}
+
+// appendClip returns the clipping paths inherited by the element along with its own one, if any, resolved
+// in the coordinates of the element; bounds returns the bounding box of the element, if needed
+// references to missing clipping paths are ignored
+func (s *parserState) appendClip(clips []Path, e element, bounds func() (Box, error)) ([]Path, error) {
+	id, ok := clipReference(newAttributes(e.Attrs))
+	if !ok {
+		return clips, nil
+	}
+	definition, ok := s.ClipPaths[id]
+	if !ok {
+		return clips, nil
+	}
+
+	clip, err := s.clipContent(id, definition)
+	if err != nil {
+		return nil, err
+	}
+	// the content is expressed in fractions of the bounding box of the element
+	if definition.attr("", clipPathUnitsAttribute) == objectBoundingBoxValue {
+		box, err := bounds()
+		if err != nil {
+			return nil, err
+		}
+		if box.IsEmpty() {
+			// elements without a bounding box are clipped entirely
+			clip.Data = nil
+		} else {
+			size := box.Size()
+			clip = clip.Transform(Translate(box.Min.X, box.Min.Y).Multiply(Scale(size.X, size.Y)))
+		}
+	}
+
+	// copies the inherited clipping paths, since they are shared by the siblings
+	return append(append([]Path(nil), clips...), clip), nil
+}
+
+// clipContent returns the paths of a clipping path definition combined into a single path,
+// with their transforms and the one of the definition applied; the content is parsed once per definition
+// the path is clipped with the clip rule of its first child, if set, or of the definition
+func (s *parserState) clipContent(id string, definition element) (Path, error) {
+	if clip, ok := s.Clips[id]; ok {
+		return clip, nil
+	}
+
+	clip := Path{
+		ID:         id,
+		Label:      definition.Label(),
+		Attributes: newAttributes(definition.Attrs),
+	}
+	transform, err := elementTransform(definition)
+	if err != nil {
+		return Path{}, err
+	}
+
+	for _, child := range definition.Elements {
+		if child.XMLName.Local != pathElementTag {
+			continue
+		}
+
+		pathData, err := s.parsePath(child)
+		if err != nil {
+			return Path{}, err
+		}
+		childTransform, err := elementTransform(child)
+		if err != nil {
+			return Path{}, err
+		}
+
+		if rule, ok := newAttributes(child.Attrs).Property(clipRuleProperty); ok && len(clip.Data) == 0 {
+			clip.Attributes = clip.Attributes.set("", clipRuleProperty, rule)
+		}
+		clip.Data = append(clip.Data, Path{Data: pathData}.Transform(transform.Multiply(childTransform)).Data...)
+	}
+
+	// the content is flattened once, instead of once per clipped path, and its lines are counted once
+	if s.Options.ExactClipping {
+		tolerance := s.Options.FlatteningTolerance
+		// the content in fractions of a bounding box is flattened within a fraction of the box
+		if definition.attr("", clipPathUnitsAttribute) == objectBoundingBoxValue {
+			tolerance = boundingBoxFlatteningTolerance
+		}
+		if clip.Data, err = s.reflatten(clip.Data, tolerance); err != nil {
+			return Path{}, err
+		}
+	}
+
+	if s.Clips == nil {
+		s.Clips = make(map[string]Path)
+	}
+	s.Clips[id] = clip
+
+	return clip, nil
+}
+
+// elementTransform returns the transformation of the "transform" attribute of the element
+func elementTransform(e element) (Transform, error) {
+	value := e.attr("", transformAttribute)
+	if strings.TrimSpace(value) == "" {
+		return Identity(), nil
+	}
+	return ParseTransform(value)
+}
+
+// elementBounds returns the bounding box of the paths inside the group, used by the clipping paths expressed in
+// fractions of the bounding box of a group; layer and included are the ones of the group (see groupLayer)
+// only the paths kept by the layer filters are considered, parsed as they will be when parsing the content of
+// the group, so that they are parsed and counted once; the bounding boxes are computed once per group
+func (s *parserState) elementBounds(e *element, layer string, included bool) (Box, error) {
+	if box, ok := s.Bounds[e]; ok {
+		return box, nil
+	}
+
+	box := emptyBox()
+	for i := range e.Elements {
+		child := &e.Elements[i]
+		switch child.XMLName.Local {
+		case groupElementTag:
+			childLayer, childIncluded, excluded := s.groupLayer(*child, layer, included)
+			if excluded {
+				continue
+			}
+
+			childBox, err := s.elementBounds(child, childLayer, childIncluded)
+			if err != nil {
+				return Box{}, err
+			}
+			box = box.Union(childBox)
+		case pathElementTag:
+			if !included {
+				continue
+			}
+
+			pathData, err := s.parsePathOnce(child)
+			if err != nil {
+				return Box{}, err
+			}
+			if s.PathData == nil {
+				s.PathData = make(map[*element][]PathData)
+			}
+			s.PathData[child] = pathData
+			box = box.Union(Path{Data: pathData}.Bounds())
+		}
+	}
+
+	if s.Bounds == nil {
+		s.Bounds = make(map[*element]Box)
+	}
+	s.Bounds[e] = box
+
+	return box, nil
+}
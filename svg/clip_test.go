@@ -0,0 +1,141 @@
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// boundingBoxClip is a clipping path covering the whole bounding box of the element it clips
+const boundingBoxClip = `<clipPath id="c" clipPathUnits="objectBoundingBox"><path d="M0 0 H1 V1 H0 Z"/></clipPath>`
+
+func TestGroupBoundingBoxClip(t *testing.T) {
+	document := `<svg xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape">` + boundingBoxClip + `
+		<g id="outer" clip-path="url(#c)">
+			<g id="inner" clip-path="url(#c)"><path id="p" d="M0 0 L10 0 L10 10 Z"/></g>
+			<g inkscape:groupmode="layer" inkscape:label="Hidden"><path id="h" d="M0 0 L100 100"/></g>
+		</g>
+	</svg>`
+
+	tests := []struct {
+		name    string
+		options ParserOptions
+		outer   Box
+	}{
+		{"every layer", ParserOptions{}, Box{Max: vector.Vector2{X: 100, Y: 100}}},
+		// the paths of excluded layers are not part of the bounding box
+		{"excluded layer", ParserOptions{ExcludeLayers: []string{"Hidden"}}, Box{Max: vector.Vector2{X: 10, Y: 10}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := ParsePath([]byte(document), test.options)
+			if err != nil || len(paths) == 0 || paths[0].ID != "p" {
+				t.Fatalf("ParsePath returned %d paths and the error %v", len(paths), err)
+			}
+
+			clip := paths[0].Clip
+			inner := Box{Max: vector.Vector2{X: 10, Y: 10}}
+			if len(clip) != 2 || clip[0].Bounds() != test.outer || clip[1].Bounds() != inner {
+				t.Fatalf("Clip = %v, expected the bounding boxes %v and %v", clip, test.outer, inner)
+			}
+			if !approxEqual(paths[0].Area(), 50, 1e-9) {
+				t.Errorf("Area() = %v, expected 50", paths[0].Area())
+			}
+		})
+	}
+}
+
+// checkSegmentLimit checks that the document is parsed within the segment limit of the options, but not within one less
+func checkSegmentLimit(t *testing.T, document string, options ParserOptions) {
+	t.Helper()
+	if _, err := ParsePath([]byte(document), options); err != nil {
+		t.Fatalf("unexpected error within %d segments: %v", options.MaxSegments, err)
+	}
+
+	options.MaxSegments--
+	_, err := ParsePath([]byte(document), options)
+	var limitErr LimitExceededError
+	if !errors.As(err, &limitErr) || limitErr.Limit != segmentCountLimit {
+		t.Fatalf("expected the %s limit of %d to be exceeded, got %v", segmentCountLimit, options.MaxSegments, err)
+	}
+}
+
+func TestExactClippingLimits(t *testing.T) {
+	// many paths inside the same clipping path, which does not cut them
+	const paths = 100
+	var document strings.Builder
+	document.WriteString(`<svg><clipPath id="c"><path d="M-1 -1 H1000 V1000 H-1 Z"/></clipPath><g clip-path="url(#c)">`)
+	for i := 0; i < paths; i++ {
+		document.WriteString(fmt.Sprintf(`<path d="M%d 0 h1 v1 h-1 Z"/>`, 2*i))
+	}
+	document.WriteString(`</g></svg>`)
+
+	// the lines of the paths and the ones of the clipping path are counted once
+	for _, exact := range []bool{false, true} {
+		checkSegmentLimit(t, document.String(), ParserOptions{MaxSegments: 4*paths + 4, ExactClipping: exact})
+	}
+
+	// the curves are counted as the lines they are flattened into
+	curved := `<svg><clipPath id="c"><path d="M-1 -1 H10 V10 H-1 Z"/></clipPath>` +
+		`<path clip-path="url(#c)" d="M0 0 C 3 3 6 3 9 0 Z"/></svg>`
+	flattened, err := ParsePath([]byte(curved), ParserOptions{FlatteningTolerance: 0.01})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSegmentLimit(t, curved, ParserOptions{MaxSegments: len(flattened[0].Data) + 4, FlatteningTolerance: 0.01, ExactClipping: true})
+}
+
+func TestGroupBoundingBoxClipLimits(t *testing.T) {
+	// the path is inside many nested groups clipped by their bounding boxes
+	const depth, segments = 200, 5000
+	document := `<svg>` + boundingBoxClip +
+		strings.Repeat(`<g clip-path="url(#c)">`, depth) +
+		`<path d="M0 0` + strings.Repeat(" L1 1", segments) + `"/>` +
+		strings.Repeat(`</g>`, depth) + `</svg>`
+
+	// the path is counted once, along with the four segments of the clipping path, even when clipped exactly
+	checkSegmentLimit(t, document, ParserOptions{MaxSegments: segments + 4})
+	checkSegmentLimit(t, document, ParserOptions{MaxSegments: segments + 4, ExactClipping: true})
+
+	// the path is parsed once, not once per group
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := ParsePath([]byte(document), ParserOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	runtime.ReadMemStats(&after)
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 32<<20 {
+		t.Errorf("allocated %d bytes, expected the path to be parsed once", allocated)
+	}
+}
+
+func TestClipRule(t *testing.T) {
+	document := `<svg><clipPath id="c"><path clip-rule="evenodd" d="M0 0 H4 V4 H0 Z M1 1 H3 V3 H1 Z"/></clipPath>` +
+		`<path id="p" clip-path="url(#c)" d="M0 0 H4 V4 H0 Z"/></svg>`
+	paths, err := ParsePath([]byte(document), ParserOptions{})
+	if err != nil || len(paths) != 1 || len(paths[0].Clip) != 1 {
+		t.Fatalf("ParsePath returned %v and the error %v", paths, err)
+	}
+
+	// the clipping path takes the clip rule of its first child
+	if rule := paths[0].Clip[0].ClipRule(); rule != EvenOdd {
+		t.Errorf("ClipRule() = %v, expected EvenOdd", rule)
+	}
+	if clipped := paths[0].ApplyClip(0); !approxEqual(clipped.Area(), 12, 1e-9) {
+		t.Errorf("the clipped path has the area %v, expected 12", clipped.Area())
+	}
+
+	// setting an attribute does not modify the attributes it was set on
+	attributes := newAttributes([]xml.Attr{{Name: xml.Name{Local: clipRuleProperty}, Value: "nonzero"}})
+	if set := attributes.set("", clipRuleProperty, evenOddValue); len(set) != 1 || set[0].Value != evenOddValue || attributes[0].Value != "nonzero" {
+		t.Errorf("set replaced %v into %v", attributes, set)
+	}
+	if set := attributes[:0].set("", fillProperty, noneValue); len(set) != 1 || attributes[0].Value != "nonzero" {
+		t.Errorf("set appended %v over %v", set, attributes)
+	}
+}
@@ -0,0 +1,82 @@
+package svg
+
+// For more information on the gzip format:
+// - https://www.rfc-editor.org/rfc/rfc1952
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+)
+
+// default maximum size of decompressed SVGZ data (64 MiB)
+const defaultMaxDecompressedSize = 64 << 20
+
+// gzip magic header
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzip checks if the given data starts with the gzip magic header
+func isGzip(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
+
+// decompress returns the given data, decompressed if it is gzip-compressed
+func decompress(data []byte, options ParserOptions) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+
+	return readGzip(bytes.NewReader(data), options)
+}
+
+// readAll reads all the data from the given reader, decompressing it if it is gzip-compressed
+// uncompressed data is read up to the same maximum size as decompressed data
+func readAll(r io.Reader, options ParserOptions) ([]byte, error) {
+	reader := bufio.NewReader(r)
+	// the error is ignored, since a short read simply means it is not gzip data
+	if magic, _ := reader.Peek(len(gzipMagic)); !isGzip(magic) {
+		return readLimited(reader, maxDecompressedSize(options), readSizeLimit)
+	}
+
+	return readGzip(reader, options)
+}
+
+// readGzip decompresses the data from the given reader, up to the maximum decompressed size
+func readGzip(r io.Reader, options ParserOptions) ([]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return readLimited(gz, maxDecompressedSize(options), decompressedSizeLimit)
+}
+
+// readLimited reads all the data from the given reader, up to the given size, where a negative size disables the limit
+// the name of the limit is reported when it is exceeded
+func readLimited(r io.Reader, limit int64, name string) ([]byte, error) {
+	if limit < 0 {
+		return ioutil.ReadAll(r)
+	}
+
+	// reads one more byte than allowed to detect that the limit was exceeded
+	data, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, newLimitExceededError(name, float64(limit))
+	}
+
+	return data, nil
+}
+
+// maxDecompressedSize returns the maximum decompressed size, falling back to the default one
+func maxDecompressedSize(options ParserOptions) int64 {
+	if options.MaxDecompressedSize == 0 {
+		return defaultMaxDecompressedSize
+	}
+	return options.MaxDecompressedSize
+}
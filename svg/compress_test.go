@@ -0,0 +1,72 @@
+package svg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+)
+
+// gzipData returns the given data compressed with gzip
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return compressed.Bytes()
+}
+
+func TestParseCompressed(t *testing.T) {
+	document := []byte(corpus[0])
+	expected, err := ParsePath(document, ParserOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		// whether the data is a document once decompressed
+		valid bool
+	}{
+		{"uncompressed", document, true},
+		{"compressed", gzipData(t, document), true},
+		// the data is decompressed once, whichever variant reads it
+		{"compressed twice", gzipData(t, gzipData(t, document)), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := map[string]func() ([]Path, error){
+				"ParsePath": func() ([]Path, error) { return ParsePath(test.data, ParserOptions{}) },
+				"ParsePathReader": func() ([]Path, error) {
+					return ParsePathReader(bytes.NewReader(test.data), ParserOptions{})
+				},
+				"ParseDocument": func() ([]Path, error) {
+					root, err := ParseDocument(test.data, ParserOptions{})
+					return root.Paths, err
+				},
+				"ParseDocumentReader": func() ([]Path, error) {
+					root, err := ParseDocumentReader(bytes.NewReader(test.data), ParserOptions{})
+					return root.Paths, err
+				},
+			}
+			for name, parse := range results {
+				paths, err := parse()
+				if !test.valid {
+					if err == nil {
+						t.Errorf("%s: expected an error, got %d paths", name, len(paths))
+					}
+					continue
+				}
+				if err != nil || !reflect.DeepEqual(paths, expected) {
+					t.Errorf("%s = (%v, %v), expected %v", name, paths, err, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package svg
+
+// For more information on fill rules:
+// - https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/fill-rule
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// FillRule represents the rule that determines which points are inside a shape
+type FillRule int
+
+const (
+	// NonZero considers a point inside when the path winds around it a non-zero number of times
+	NonZero FillRule = iota
+	// EvenOdd considers a point inside when a ray from it crosses the path an odd number of times
+	EvenOdd
+)
+
+// fill rule property
+const (
+	fillRuleProperty = "fill-rule"
+	evenOddValue     = "evenodd"
+)
+
+// HitOptions are used to configure a hit test
+type HitOptions struct {
+	// rule that determines the inside of the fill
+	FillRule FillRule
+	// when greater than zero, the points within half this width from the path (its stroke) are also hit
+	StrokeWidth float64
+	// ignores the fill, testing only the stroke
+	StrokeOnly bool
+}
+
+// FillRule returns the fill rule of the path, as defined by its "fill-rule" property
+// defaults to NonZero, as in SVG
+func (p Path) FillRule() FillRule {
+	if value, ok := p.Attributes.Property(fillRuleProperty); ok && value == evenOddValue {
+		return EvenOdd
+	}
+	return NonZero
+}
+
+// Winding returns the winding number of the path around the given point,
+// which counts how many times the path winds counter-clockwise around it (in a y-up coordinate system)
+// like in SVG filling, open subpaths are implicitly closed by a straight line
+func (p Path) Winding(point vector.Vector2) int {
+	var winding int
+	for _, subpath := range p.Subpaths() {
+		for _, data := range subpath.Data {
+			winding += data.winding(point)
+		}
+		if !subpath.IsClosed() {
+			winding += newLine(subpath.Data[len(subpath.Data)-1].End, subpath.Data[0].Start).winding(point)
+		}
+	}
+
+	return winding
+}
+
+// winding returns the contribution of the curve to the winding number around the given point,
+// counting the crossings of a horizontal ray from the point towards +x
+// the endpoints follow the half-open rule: upward crossings include the start, downward crossings include the end
+func (p PathData) winding(point vector.Vector2) int {
+	// the ray can only cross the curve if it is within its vertical range and not entirely to its left
+	box := p.controlBox()
+	if point.Y < box.Min.Y || point.Y > box.Max.Y || point.X > box.Max.X {
+		return 0
+	}
+
+	a, b, c, d := bernsteinToPower(p.Start.Y-point.Y, p.Control[0].Y-point.Y, p.Control[1].Y-point.Y, p.End.Y-point.Y)
+	var winding int
+	for _, t := range uniqueRoots(solveCubic(a, b, c, d)) {
+		const tolerance = 1e-9
+		if t < -tolerance || t > 1+tolerance {
+			continue
+		}
+		// snaps the roots to the exact endpoints
+		switch {
+		case t < tolerance && p.Start.Y == point.Y:
+			t = 0
+		case t > 1-tolerance && p.End.Y == point.Y:
+			t = 1
+		}
+		t = mathf.Clamp(t, 0, 1)
+
+		if p.Point(t).X <= point.X {
+			continue
+		}
+
+		switch direction := p.verticalDirection(t); {
+		case direction > 0 && t < 1:
+			winding++
+		case direction < 0 && t > 0:
+			winding--
+		}
+	}
+
+	return winding
+}
+
+// verticalDirection returns the sign of the vertical movement of the curve at t
+// tangent points, where the curve touches but does not cross a horizontal line, have no direction
+func (p PathData) verticalDirection(t float64) int {
+	before := p.Point(math.Max(0, t-tangentOffset)).Y
+	after := p.Point(math.Min(1, t+tangentOffset)).Y
+	current := p.Point(t).Y
+
+	// at the endpoints, only one side of the curve exists
+	switch {
+	case t <= 0:
+		before = current - (after - current)
+	case t >= 1:
+		after = current + (current - before)
+	}
+
+	if before < current && current < after {
+		return 1
+	} else if before > current && current > after {
+		return -1
+	}
+	return 0
+}
+
+// uniqueRoots returns the given roots without duplicates
+func uniqueRoots(roots []float64) []float64 {
+	var unique []float64
+	for _, root := range roots {
+		duplicate := false
+		for _, existing := range unique {
+			if math.Abs(existing-root) < epsilon {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, root)
+		}
+	}
+
+	return unique
+}
+
+// Contains checks if the given point is inside the fill of the path, according to the fill rule
+// the curves are tested exactly, without being flattened
+func (p Path) Contains(point vector.Vector2, rule FillRule) bool {
+	winding := p.Winding(point)
+	if rule == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// ContainsStroke checks if the given point is inside the stroke of the path, with the given width
+// the stroke is the band within half its width from the path, with round joins and caps
+func (p Path) ContainsStroke(point vector.Vector2, width float64) bool {
+	if width <= 0 {
+		return false
+	}
+	return p.Nearest(point).Distance <= 0.5*width
+}
+
+// Hit checks if the given point hits the path, i.e. if it is inside its fill or its stroke
+func (p Path) Hit(point vector.Vector2, options HitOptions) bool {
+	if !options.StrokeOnly && p.Contains(point, options.FillRule) {
+		return true
+	}
+	return p.ContainsStroke(point, options.StrokeWidth)
+}
@@ -0,0 +1,89 @@
+package svg
+
+import (
+	"encoding/xml"
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+func TestContains(t *testing.T) {
+	// a pentagram, which winds twice around its centre and once around its tips
+	var star []vector.Vector2
+	for i := 0; i < 5; i++ {
+		angle := math.Pi/2 + float64(i)*4*math.Pi/5
+		star = append(star, vector.Vector2{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)})
+	}
+	pentagram := polygonPath(star...)
+	// two squares in the same direction, one inside the other
+	nested := square(0, 0, 4)
+	nested.Data = append(nested.Data, square(1, 1, 2).Data...)
+	// a diamond, whose left and right vertices are level with its centre
+	diamond := polygonPath(vector.Vector2{Y: -2}, vector.Vector2{X: 2}, vector.Vector2{Y: 2}, vector.Vector2{X: -2})
+	// an open subpath, implicitly closed by a straight line
+	open := Path{Data: []PathData{newLine(vector.Vector2{}, vector.Vector2{X: 4}), newLine(vector.Vector2{X: 4}, vector.Vector2{X: 4, Y: 4})}}
+
+	tests := []struct {
+		name             string
+		path             Path
+		point            vector.Vector2
+		winding          int
+		nonZero, evenOdd bool
+	}{
+		{"pentagram centre", pentagram, vector.Vector2{}, 2, true, false},
+		{"pentagram tip", pentagram, vector.Vector2{Y: 8}, 1, true, true},
+		{"outside the pentagram", pentagram, vector.Vector2{X: 9, Y: 9}, 0, false, false},
+		{"inner square", nested, vector.Vector2{X: 2, Y: 2}, 2, true, false},
+		{"between the squares", nested, vector.Vector2{X: 0.5, Y: 2}, 1, true, true},
+		{"reversed inner square", Path{Data: append(square(0, 0, 4).Data, square(1, 1, 2).Reverse().Data...)}, vector.Vector2{X: 2, Y: 2}, 0, false, false},
+		// the ray from the point goes through a vertex
+		{"diamond centre", diamond, vector.Vector2{}, 1, true, true},
+		{"level with the diamond", diamond, vector.Vector2{X: -3}, 0, false, false},
+		{"open subpath", open, vector.Vector2{X: 3, Y: 1}, 1, true, true},
+		{"circle", circle(vector.Vector2{}, 10), vector.Vector2{X: 7, Y: 7}, 1, true, true},
+		{"outside the circle", circle(vector.Vector2{}, 10), vector.Vector2{X: 7.1, Y: 7.1}, 0, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if winding := test.path.Winding(test.point); math.Abs(float64(winding)) != float64(test.winding) {
+				t.Errorf("Winding() = %d, expected ±%d", winding, test.winding)
+			}
+			if inside := test.path.Contains(test.point, NonZero); inside != test.nonZero {
+				t.Errorf("Contains(NonZero) = %v, expected %v", inside, test.nonZero)
+			}
+			if inside := test.path.Contains(test.point, EvenOdd); inside != test.evenOdd {
+				t.Errorf("Contains(EvenOdd) = %v, expected %v", inside, test.evenOdd)
+			}
+		})
+	}
+}
+
+func TestHit(t *testing.T) {
+	path := square(0, 0, 4)
+	path.Attributes = Attributes{{Name: xml.Name{Local: fillRuleProperty}, Value: evenOddValue}}
+	path.Data = append(path.Data, square(1, 1, 2).Data...)
+	if rule := path.FillRule(); rule != EvenOdd {
+		t.Fatalf("FillRule() = %v, expected EvenOdd", rule)
+	}
+
+	tests := []struct {
+		name    string
+		point   vector.Vector2
+		options HitOptions
+		hit     bool
+	}{
+		{"fill", vector.Vector2{X: 0.5, Y: 2}, HitOptions{FillRule: EvenOdd}, true},
+		{"hole", vector.Vector2{X: 2, Y: 2}, HitOptions{FillRule: EvenOdd}, false},
+		{"hole filled by the nonzero rule", vector.Vector2{X: 2, Y: 2}, HitOptions{FillRule: NonZero}, true},
+		{"stroke outside", vector.Vector2{X: -0.4, Y: 2}, HitOptions{StrokeWidth: 1}, true},
+		{"beyond the stroke", vector.Vector2{X: -0.6, Y: 2}, HitOptions{StrokeWidth: 1}, false},
+		{"stroke only", vector.Vector2{X: 0.5, Y: 2}, HitOptions{StrokeWidth: 0.5, StrokeOnly: true}, false},
+		{"stroke of the hole", vector.Vector2{X: 1.2, Y: 2}, HitOptions{FillRule: EvenOdd, StrokeWidth: 0.5, StrokeOnly: true}, true},
+	}
+	for _, test := range tests {
+		if hit := path.Hit(test.point, test.options); hit != test.hit {
+			t.Errorf("Hit() in the %s = %v, expected %v", test.name, hit, test.hit)
+		}
+	}
+}
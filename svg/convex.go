@@ -0,0 +1,129 @@
+package svg
+
+// For more information on the Hertel–Mehlhorn algorithm:
+// - https://en.wikipedia.org/wiki/Polygon_partition#Partition_into_convex_polygons
+
+import (
+	"math"
+	"sort"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// minConvexVertices is the smallest vertex limit of a convex piece, a triangle
+const minConvexVertices = 3
+
+// ConvexPolygons decomposes the fill of the path into convex polygons with at most the given number of vertices
+// (e.g. 8 for Box2D), where zero or less means no limit; the polygons are counter-clockwise, without collinear vertices
+// the fill is triangulated (see Triangulate) and the triangles are merged back into convex pieces with the
+// Hertel–Mehlhorn algorithm, which removes the diagonals that are not needed, from the longest to the shortest
+func (p Path) ConvexPolygons(maxVertices int, tolerance float64) [][]vector.Vector2 {
+	if maxVertices > 0 && maxVertices < minConvexVertices {
+		maxVertices = minConvexVertices
+	}
+	mesh := p.Triangulate(tolerance)
+
+	// every piece starts as a triangle, and every directed edge belongs to the piece on its left
+	pieces := make([][]int, 0, mesh.Triangles())
+	owners := make(map[[2]int]int)
+	for i := 0; i < len(mesh.Indices); i += 3 {
+		piece := []int{mesh.Indices[i], mesh.Indices[i+1], mesh.Indices[i+2]}
+		for k := range piece {
+			owners[[2]int{piece[k], piece[(k+1)%len(piece)]}] = len(pieces)
+		}
+		pieces = append(pieces, piece)
+	}
+
+	// the diagonals are the edges shared by two pieces, in both directions
+	var diagonals [][2]int
+	for e := range owners {
+		if _, ok := owners[[2]int{e[1], e[0]}]; ok && e[0] < e[1] {
+			diagonals = append(diagonals, e)
+		}
+	}
+	length := func(e [2]int) float64 {
+		return mesh.Vertices[e[0]].DistanceSqr(mesh.Vertices[e[1]])
+	}
+	sort.Slice(diagonals, func(m, n int) bool {
+		if lm, ln := length(diagonals[m]), length(diagonals[n]); lm != ln {
+			return lm > ln
+		}
+		// keeps the order deterministic, since the diagonals come from a map
+		return diagonals[m][0] < diagonals[n][0] || (diagonals[m][0] == diagonals[n][0] && diagonals[m][1] < diagonals[n][1])
+	})
+
+	for _, diagonal := range diagonals {
+		a, b := owners[diagonal], owners[[2]int{diagonal[1], diagonal[0]}]
+		if a == b {
+			continue
+		}
+
+		merged := mergePieces(pieces[a], pieces[b], diagonal)
+		points := convexVertices(mesh.Vertices, merged)
+		if points == nil || (maxVertices > 0 && len(points) > maxVertices) {
+			continue
+		}
+
+		pieces[a], pieces[b] = merged, nil
+		for k := range merged {
+			owners[[2]int{merged[k], merged[(k+1)%len(merged)]}] = a
+		}
+		delete(owners, diagonal)
+		delete(owners, [2]int{diagonal[1], diagonal[0]})
+	}
+
+	var polygons [][]vector.Vector2
+	for _, piece := range pieces {
+		if piece == nil {
+			continue
+		}
+		if points := convexVertices(mesh.Vertices, piece); len(points) >= minConvexVertices {
+			polygons = append(polygons, points)
+		}
+	}
+
+	return polygons
+}
+
+// mergePieces merges two pieces across their shared diagonal, which the first one runs from its first vertex
+// to its second vertex and the other one backwards
+func mergePieces(first, second []int, diagonal [2]int) []int {
+	// rotates the first piece to run from the end of the diagonal to its start, and the second one the other way
+	rotate := func(piece []int, start int) []int {
+		for i, vertex := range piece {
+			if vertex == start {
+				return append(append([]int(nil), piece[i:]...), piece[:i]...)
+			}
+		}
+		return piece
+	}
+	first = rotate(first, diagonal[1])
+	second = rotate(second, diagonal[0])
+
+	// the diagonal endpoints are kept once, from the first piece
+	return append(first, second[1:len(second)-1]...)
+}
+
+// convexVertices returns the positions of the vertices of the counter-clockwise piece, without its collinear vertices,
+// or nil if the piece is not convex
+func convexVertices(vertices []vector.Vector2, piece []int) []vector.Vector2 {
+	var points []vector.Vector2
+	for k, vertex := range piece {
+		previous := vertices[piece[(k+len(piece)-1)%len(piece)]]
+		current := vertices[vertex]
+		next := vertices[piece[(k+1)%len(piece)]]
+
+		in, out := current.Sub(previous), next.Sub(current)
+		turn := in.Cross(out)
+		// the tolerance is relative to the edges, so that it does not depend on the scale
+		tolerance := epsilon * math.Max(in.Magnitude()*out.Magnitude(), epsilon)
+		if turn < -tolerance {
+			return nil
+		}
+		if turn > tolerance {
+			points = append(points, current)
+		}
+	}
+
+	return points
+}
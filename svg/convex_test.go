@@ -0,0 +1,52 @@
+package svg
+
+import (
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+func TestConvexPolygons(t *testing.T) {
+	withHole := square(0, 0, 4)
+	withHole.Data = append(withHole.Data, square(1, 1, 2).Reverse().Data...)
+	shapes := []struct {
+		name string
+		path Path
+	}{
+		{"square", square(0, 0, 2)},
+		{"L shape", polygonPath(vector.Vector2{}, vector.Vector2{X: 3}, vector.Vector2{X: 3, Y: 1}, vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 1, Y: 3}, vector.Vector2{Y: 3})},
+		{"square with a hole", withHole},
+		{"circle", circle(vector.Vector2{X: 5, Y: 5}, 10)},
+	}
+
+	for _, shape := range shapes {
+		fill := meshArea(t, shape.path.Triangulate(0))
+		for _, maxVertices := range []int{0, 3, 4, 8} {
+			pieces := shape.path.ConvexPolygons(maxVertices, 0)
+			var area float64
+			for _, piece := range pieces {
+				if maxVertices > 0 && len(piece) > maxVertices {
+					t.Errorf("%s, at most %d vertices: a piece has %d vertices", shape.name, maxVertices, len(piece))
+				}
+				// every turn is to the left, so the piece is convex and counter-clockwise
+				for i := range piece {
+					a, b, c := piece[i], piece[(i+1)%len(piece)], piece[(i+2)%len(piece)]
+					if b.Sub(a).Cross(c.Sub(b)) <= 0 {
+						t.Errorf("%s, at most %d vertices: the piece %v is not strictly convex at %v", shape.name, maxVertices, piece, b)
+						break
+					}
+				}
+				area += polygonArea(piece)
+			}
+			// the pieces cover the fill without overlapping
+			if !approxEqual(area, fill, 1e-9) {
+				t.Errorf("%s, at most %d vertices: the pieces add up to %v, expected %v", shape.name, maxVertices, area, fill)
+			}
+		}
+	}
+
+	// a convex shape is a single piece when there is no limit
+	if pieces := square(0, 0, 2).ConvexPolygons(0, 0); len(pieces) != 1 || len(pieces[0]) != 4 {
+		t.Errorf("ConvexPolygons(0) of a square = %v, expected the square", pieces)
+	}
+}
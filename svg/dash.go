@@ -0,0 +1,122 @@
+package svg
+
+// For more information on dashing:
+// - https://www.w3.org/TR/SVG2/painting.html#StrokeDashing
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// dash properties
+const (
+	strokeDashArrayProperty  = "stroke-dasharray"
+	strokeDashOffsetProperty = "stroke-dashoffset"
+	dashSplitter             = ","
+	// maximum number of dashes of a path, beyond which the path is not dashed
+	maxDashes = 10000
+)
+
+// DashPattern returns the dash array and offset of the path, as defined by its "stroke-dasharray" and
+// "stroke-dashoffset" properties
+// a missing or invalid dash array (e.g. "none" or with negative values) returns nil, meaning a solid stroke
+func (p Path) DashPattern() ([]float64, float64) {
+	var offset float64
+	if value, ok := p.Attributes.Property(strokeDashOffsetProperty); ok {
+		if parsed, err := strconv.ParseFloat(strings.TrimSuffix(value, pixelUnit), 64); err == nil {
+			offset = parsed
+		}
+	}
+
+	value, ok := p.Attributes.Property(strokeDashArrayProperty)
+	if !ok {
+		return nil, offset
+	}
+	var array []float64
+	for _, field := range strings.Fields(strings.ReplaceAll(value, dashSplitter, " ")) {
+		length, err := strconv.ParseFloat(strings.TrimSuffix(field, pixelUnit), 64)
+		if err != nil || length < 0 {
+			return nil, offset
+		}
+		array = append(array, length)
+	}
+
+	return array, offset
+}
+
+// Dash splits the path into the dashes of the given dash array and offset, following the arc length of the curves
+// every dash returns its own open path, and the pattern restarts at every subpath, as in SVG;
+// on closed subpaths, a dash crossing the start point is kept in a single piece
+// dash arrays with an odd number of values are repeated to get an even number of values, and
+// dashes without length are skipped; an empty dash array, or one whose values are all zero, returns the whole path,
+// and so does a dash array that would split the path into more than 10000 dashes
+func (p Path) Dash(array []float64, offset float64) []Path {
+	if len(array)%2 != 0 {
+		array = append(append([]float64(nil), array...), array...)
+	}
+	var total float64
+	for _, length := range array {
+		if length < 0 {
+			return []Path{p}
+		}
+		total += length
+	}
+	if total < epsilon {
+		return []Path{p}
+	}
+	// each repetition of the pattern has up to one dash per pair of values
+	if p.Length()/total*float64(len(array)/2) > maxDashes {
+		return []Path{p}
+	}
+
+	var dashes []Path
+	for _, subpath := range p.Subpaths() {
+		dashes = append(dashes, subpath.dashSubpath(array, total, offset)...)
+	}
+
+	return dashes
+}
+
+// dashSubpath splits a continuous subpath into its dashes
+func (p Path) dashSubpath(array []float64, total, offset float64) []Path {
+	table := NewArcLengthTable(p, defaultArcLengthPrecision)
+	length := table.Length()
+
+	// finds the position in the pattern at the start of the subpath
+	position := math.Mod(offset, total)
+	if position < 0 {
+		position += total
+	}
+	index := 0
+	for position >= array[index] {
+		position -= array[index]
+		index = (index + 1) % len(array)
+	}
+	remaining := array[index] - position
+
+	var dashes []Path
+	// whether the first dash starts at the start point and the last one ends at the end point
+	var startsOn, endsOn bool
+	for distance := 0.0; distance < length; {
+		step := math.Min(remaining, length-distance)
+		if index%2 == 0 && step > epsilon {
+			dashes = append(dashes, table.Section(distance, distance+step))
+			startsOn = startsOn || distance < epsilon
+			endsOn = distance+step > length-epsilon
+		}
+
+		distance += step
+		index = (index + 1) % len(array)
+		remaining = array[index]
+	}
+
+	// joins the dash crossing the start point of closed subpaths
+	if len(dashes) > 1 && startsOn && endsOn && p.IsClosed() {
+		last := len(dashes) - 1
+		dashes[last].Data = append(dashes[last].Data, dashes[0].Data...)
+		dashes = dashes[1:]
+	}
+
+	return dashes
+}
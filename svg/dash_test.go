@@ -0,0 +1,118 @@
+package svg
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+func TestDashPattern(t *testing.T) {
+	tests := []struct {
+		array, offset string
+		dashes        []float64
+		dashOffset    float64
+	}{
+		{"5, 3 2px", "1px", []float64{5, 3, 2}, 1},
+		{"4", "-2", []float64{4}, -2},
+		{"none", "", nil, 0},
+		{"1 -2", "3", nil, 3},
+	}
+	for _, test := range tests {
+		path := Path{Attributes: Attributes{
+			{Name: xml.Name{Local: strokeDashArrayProperty}, Value: test.array},
+			{Name: xml.Name{Local: strokeDashOffsetProperty}, Value: test.offset},
+		}}
+		if array, offset := path.DashPattern(); !reflect.DeepEqual(array, test.dashes) || offset != test.dashOffset {
+			t.Errorf("DashPattern() of %q and %q = (%v, %v), expected (%v, %v)", test.array, test.offset, array, offset, test.dashes, test.dashOffset)
+		}
+	}
+}
+
+func TestDash(t *testing.T) {
+	line := Path{Data: []PathData{newLine(vector.Vector2{}, vector.Vector2{X: 10})}}
+	square := square(0, 0, 10)
+
+	tests := []struct {
+		name   string
+		path   Path
+		array  []float64
+		offset float64
+		// lengths of the dashes, in order
+		lengths []float64
+	}{
+		{"line", line, []float64{2, 1}, 0, []float64{2, 2, 2, 1}},
+		{"line with an offset", line, []float64{2, 1}, 1, []float64{1, 2, 2, 2}},
+		// the line starts in the gap
+		{"negative offset", line, []float64{2, 1}, -1, []float64{2, 2, 2}},
+		{"odd array", line, []float64{1}, 0, []float64{1, 1, 1, 1, 1}},
+		{"zero-length dashes", line, []float64{0, 4}, 0, nil},
+		{"solid", line, []float64{0, 0}, 0, []float64{10}},
+		{"empty array", line, nil, 0, []float64{10}},
+		// the last gap ends at the start point, so no dash crosses it
+		{"square", square, []float64{3, 2}, 0, []float64{3, 3, 3, 3, 3, 3, 3, 3}},
+		// the last dash continues after the start point
+		{"square crossing the start", square, []float64{3, 2}, 2, []float64{3, 3, 3, 3, 3, 3, 3, 3}},
+		// the last dash ends at the start point only within rounding errors
+		{"square crossing the start after rounding", square, []float64{1.3, 1.3}, 0.3, append(repeat(1.3, 14), 2.3)},
+		// the pattern restarts at every subpath
+		{"subpaths", Path{Data: append(append([]PathData(nil), line.Data...), newLine(vector.Vector2{Y: 5}, vector.Vector2{X: 3, Y: 5}))}, []float64{2, 1}, 0, []float64{2, 2, 2, 1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dashes := test.path.Dash(test.array, test.offset)
+			if len(dashes) != len(test.lengths) {
+				t.Fatalf("got %d dashes, expected %d", len(dashes), len(test.lengths))
+			}
+			for i, dash := range dashes {
+				if length := dash.Length(); !approxEqual(length, test.lengths[i], 1e-5) {
+					t.Errorf("dash %d has the length %v, expected %v", i, length, test.lengths[i])
+				}
+				if len(dash.Subpaths()) != 1 {
+					t.Errorf("dash %d is not continuous", i)
+				}
+			}
+		})
+	}
+
+	// the dash crossing the start point goes through it, from the end of the subpath to its start
+	dashes := square.Dash([]float64{3, 2}, 2)
+	crossing := dashes[len(dashes)-1]
+	if !approxPoint(crossing.Data[0].Start, vector.Vector2{Y: 2}, 1e-6) || !approxPoint(crossing.Data[len(crossing.Data)-1].End, vector.Vector2{X: 1}, 1e-6) {
+		t.Errorf("the dash crossing the start goes from %v to %v", crossing.Data[0].Start, crossing.Data[len(crossing.Data)-1].End)
+	}
+}
+
+func TestDashClosedCurve(t *testing.T) {
+	// the lengths along the circle are not exactly representable, but the dash crossing the start is always joined
+	circle := circle(vector.Vector2{}, 10)
+	period := NewArcLengthTable(circle, defaultArcLengthPrecision).Length() / 10
+	for i := 1; i < 50; i++ {
+		offset := period / 2 * float64(i) / 50
+		if dashes := circle.Dash([]float64{period / 2, period / 2}, offset); len(dashes) != 10 {
+			t.Fatalf("with the offset %v, got %d dashes, expected 10", offset, len(dashes))
+		}
+	}
+}
+
+func TestDashLimit(t *testing.T) {
+	// a pattern splitting a long path into millions of dashes leaves it whole
+	line := Path{Data: []PathData{newLine(vector.Vector2{}, vector.Vector2{X: 1e4})}}
+	if dashes := line.Dash([]float64{1e-6}, 0); len(dashes) != 1 || !reflect.DeepEqual(dashes[0], line) {
+		t.Errorf("got %d dashes, expected the whole path", len(dashes))
+	}
+	// at the limit, the path is dashed
+	if dashes := line.Dash([]float64{1e4 / maxDashes / 2}, 0); len(dashes) != maxDashes {
+		t.Errorf("got %d dashes, expected %d", len(dashes), maxDashes)
+	}
+}
+
+// repeat returns the value n times
+func repeat(value float64, n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
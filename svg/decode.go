@@ -0,0 +1,62 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// SVG root tag
+const svgElementTag = "svg"
+
+// decode deserialises the XML data into a tree of elements, whose root is the SVG element
+// unlike xml.Unmarshal, it enforces the element count and nesting depth limits while decoding
+func decode(data []byte, limits limits) (element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	// looking for the root element
+	var root element
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return element{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != svgElementTag {
+				return element{}, xml.UnmarshalError(fmt.Sprintf("expected element type <%s> but have <%s>", svgElementTag, start.Name.Local))
+			}
+			root = element{XMLName: start.Name, Attrs: start.Attr}
+			break
+		}
+	}
+
+	// the open elements, from the root to the current one
+	// each parent only grows after its open child is closed, so the pointers remain valid
+	stack := []*element{&root}
+	count := 1
+	for len(stack) > 0 {
+		token, err := decoder.Token()
+		if err != nil {
+			return element{}, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			count++
+			if exceeds(count, limits.Elements) {
+				return element{}, newLimitExceededError(elementCountLimit, float64(limits.Elements))
+			}
+			if exceeds(len(stack), limits.Depth) {
+				return element{}, newLimitExceededError(depthLimit, float64(limits.Depth))
+			}
+
+			parent := stack[len(stack)-1]
+			parent.Elements = append(parent.Elements, element{XMLName: t.Name, Attrs: t.Attr})
+			stack = append(stack, &parent.Elements[len(parent.Elements)-1])
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return root, nil
+}
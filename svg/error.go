@@ -2,6 +2,7 @@ package svg
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -80,3 +81,127 @@ func newUnsupportedCommandError(command string) UnsupportedCommandError {
 func (e UnsupportedCommandError) Error() string {
 	return fmt.Sprintf("%s is not supported", e.Command)
 }
+
+type InvalidUnmarshalError struct {
+	Type string
+}
+
+func newInvalidUnmarshalError(t reflect.Type) InvalidUnmarshalError {
+	name := "nil"
+	if t != nil {
+		name = t.String()
+	}
+
+	return InvalidUnmarshalError{
+		Type: name,
+	}
+}
+
+func (e InvalidUnmarshalError) Error() string {
+	return fmt.Sprintf("cannot unmarshal into %s, a non-nil pointer to a struct is required", e.Type)
+}
+
+type InvalidTagError struct {
+	Field string
+	Tag   string
+}
+
+func newInvalidTagError(field, tag string) InvalidTagError {
+	return InvalidTagError{
+		Field: field,
+		Tag:   tag,
+	}
+}
+
+func (e InvalidTagError) Error() string {
+	return fmt.Sprintf("%s has an invalid svg tag: %s", e.Field, e.Tag)
+}
+
+type UnsupportedFieldTypeError struct {
+	Field string
+	Type  string
+}
+
+func newUnsupportedFieldTypeError(field string, t reflect.Type) UnsupportedFieldTypeError {
+	return UnsupportedFieldTypeError{
+		Field: field,
+		Type:  t.String(),
+	}
+}
+
+func (e UnsupportedFieldTypeError) Error() string {
+	return fmt.Sprintf("%s has an unsupported type: %s", e.Field, e.Type)
+}
+
+type InvalidAttributeError struct {
+	Field     string
+	Attribute string
+	Value     string
+}
+
+func newInvalidAttributeError(field, attribute, value string) InvalidAttributeError {
+	return InvalidAttributeError{
+		Field:     field,
+		Attribute: attribute,
+		Value:     value,
+	}
+}
+
+func (e InvalidAttributeError) Error() string {
+	return fmt.Sprintf("%s cannot hold the value of the attribute %s: %s", e.Field, e.Attribute, e.Value)
+}
+
+// resource limits
+const (
+	decompressedSizeLimit = "decompressed size"
+	readSizeLimit         = "read size"
+	elementCountLimit     = "element count"
+	depthLimit            = "nesting depth"
+	pathDataLengthLimit   = "path data length"
+	segmentCountLimit     = "segment count"
+	coordinateLimit       = "coordinate magnitude"
+)
+
+type LimitExceededError struct {
+	Limit string
+	Max   float64
+}
+
+func newLimitExceededError(limit string, max float64) LimitExceededError {
+	return LimitExceededError{
+		Limit: limit,
+		Max:   max,
+	}
+}
+
+func (e LimitExceededError) Error() string {
+	return fmt.Sprintf("%s exceeds the limit of %g", e.Limit, e.Max)
+}
+
+type NonFiniteCoordinateError struct {
+	Value float64
+}
+
+func newNonFiniteCoordinateError(value float64) NonFiniteCoordinateError {
+	return NonFiniteCoordinateError{
+		Value: value,
+	}
+}
+
+func (e NonFiniteCoordinateError) Error() string {
+	return fmt.Sprintf("coordinate is not a finite number: %g", e.Value)
+}
+
+type InvalidTransformError struct {
+	Data string
+}
+
+func newInvalidTransformError(data string) InvalidTransformError {
+	return InvalidTransformError{
+		Data: data,
+	}
+}
+
+func (e InvalidTransformError) Error() string {
+	return fmt.Sprintf("transform does not contain a valid list of transformations: %s", e.Data)
+}
@@ -0,0 +1,226 @@
+package svg
+
+// For more information on fitting cubic Bézier curves to points:
+// - Philip J. Schneider, An Algorithm for Automatically Fitting Digitized Curves, Graphics Gems (1990)
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// curve fitting settings
+const (
+	// default angle, in radians, above which a turn of the polyline is kept as a corner
+	defaultCornerAngle = math.Pi / 3
+	// maximum number of times the parameters of the points are improved before splitting the curve
+	maxFitIterations = 4
+	// factor of the tolerance below which improving the parameters is tried before splitting the curve
+	fitIterationFactor = 4
+)
+
+// FitPolyline returns the smooth cubic curves fitting the polyline, no further than the tolerance from its points
+// (zero or less uses the default, 0.1), with Schneider's least-squares algorithm
+// the polyline is split at its corners, where it turns by more than the corner angle (zero or less uses the default,
+// 60 degrees), and the curves are smooth everywhere else, including at the start of closed polylines
+func FitPolyline(points []vector.Vector2, tolerance, cornerAngle float64) []PathData {
+	if tolerance <= 0 {
+		tolerance = defaultFlatteningTolerance
+	}
+	if cornerAngle <= 0 {
+		cornerAngle = defaultCornerAngle
+	}
+
+	// skips the edges without length, which have no direction
+	var unique []vector.Vector2
+	for _, point := range points {
+		if len(unique) == 0 || point != unique[len(unique)-1] {
+			unique = append(unique, point)
+		}
+	}
+	n := len(unique)
+	switch n {
+	case 0:
+		return nil
+	case 1:
+		return []PathData{newLine(unique[0], unique[0])}
+	}
+
+	// the turns of the polyline, where the direction changes by more than the corner angle, are kept as corners
+	isCorner := func(previous, point, next vector.Vector2) bool {
+		in, out := point.Sub(previous), next.Sub(point)
+		return math.Abs(math.Atan2(in.Cross(out), in.Dot(out))) > cornerAngle
+	}
+	closed := n > 3 && unique[0] == unique[n-1]
+	corners := []int{0}
+	for i := 1; i < n-1; i++ {
+		if isCorner(unique[i-1], unique[i], unique[i+1]) {
+			corners = append(corners, i)
+		}
+	}
+	corners = append(corners, n-1)
+
+	// the start of a smooth closed polyline has the same tangent on both sides
+	var closingTangent vector.Vector2
+	if tangent := unique[1].Sub(unique[n-2]); closed && tangent.Magnitude() > epsilon && !isCorner(unique[n-2], unique[0], unique[1]) {
+		closingTangent = tangent.Normalized()
+	}
+
+	var data []PathData
+	for k := 1; k < len(corners); k++ {
+		first, last := corners[k-1], corners[k]
+		startTangent := unique[first+1].Sub(unique[first]).Normalized()
+		endTangent := unique[last].Sub(unique[last-1]).Normalized()
+		if closingTangent != (vector.Vector2{}) {
+			if first == 0 {
+				startTangent = closingTangent
+			}
+			if last == n-1 {
+				endTangent = closingTangent
+			}
+		}
+
+		for _, curve := range fitCubic(unique[first:last+1], startTangent, endTangent, tolerance) {
+			if curve.IsLine() {
+				curve = newLine(curve.Start, curve.End)
+			}
+			data = append(data, curve)
+		}
+	}
+
+	return data
+}
+
+// Fit returns a copy of the path whose runs of consecutive straight lines are replaced by smooth cubic curves
+// fitting them (see FitPolyline); the curves of the path are kept as they are
+func (p Path) Fit(tolerance, cornerAngle float64) Path {
+	p.Data = mapLineRuns(p.Data, func(points []vector.Vector2) []PathData {
+		return FitPolyline(points, tolerance, cornerAngle)
+	})
+
+	return p
+}
+
+// fitCubic fits cubic curves to the points, which have no repeated points, with the given unit tangents
+// at the start and at the end, both in the direction of the points
+func fitCubic(points []vector.Vector2, startTangent, endTangent vector.Vector2, tolerance float64) []PathData {
+	first, last := points[0], points[len(points)-1]
+	if len(points) == 2 {
+		// a single edge is fitted with the control points at a third of its length, along the tangents
+		third := first.Distance(last) / 3
+		return []PathData{{
+			Start:   first,
+			End:     last,
+			Control: [2]vector.Vector2{first.Add(startTangent.Mul(third)), last.Sub(endTangent.Mul(third))},
+		}}
+	}
+
+	parameters := chordLengthParameters(points)
+	curve := generateCubic(points, parameters, startTangent, endTangent)
+	maxError, split := fitError(points, parameters, curve)
+	if maxError <= tolerance*tolerance {
+		return []PathData{curve}
+	}
+
+	// when the error is not too large, better parameters may be enough to fit the points
+	if maxError <= fitIterationFactor*tolerance*tolerance {
+		for i := 0; i < maxFitIterations; i++ {
+			parameters = reparameterize(points, parameters, curve)
+			curve = generateCubic(points, parameters, startTangent, endTangent)
+			if maxError, split = fitError(points, parameters, curve); maxError <= tolerance*tolerance {
+				return []PathData{curve}
+			}
+		}
+	}
+
+	// splits the points where the error is the largest, keeping the curves smooth there
+	centerTangent := points[split+1].Sub(points[split-1])
+	if centerTangent.Magnitude() < epsilon {
+		centerTangent = points[split].Sub(points[split-1])
+	}
+	centerTangent = centerTangent.Normalized()
+
+	curves := fitCubic(points[:split+1], startTangent, centerTangent, tolerance)
+	return append(curves, fitCubic(points[split:], centerTangent, endTangent, tolerance)...)
+}
+
+// chordLengthParameters returns the parameters of the points, proportional to the distance along the polyline
+func chordLengthParameters(points []vector.Vector2) []float64 {
+	parameters := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		parameters[i] = parameters[i-1] + points[i].Distance(points[i-1])
+	}
+	for i := range parameters {
+		parameters[i] /= parameters[len(parameters)-1]
+	}
+
+	return parameters
+}
+
+// generateCubic returns the cubic curve, with the given tangents at its endpoints, that fits the points
+// at the given parameters with the least squared error
+func generateCubic(points []vector.Vector2, parameters []float64, startTangent, endTangent vector.Vector2) PathData {
+	first, last := points[0], points[len(points)-1]
+
+	// the control points lie along the tangents, at the distances (alphas) solving the normal equations
+	var c00, c01, c11, x0, x1 float64
+	for i, t := range parameters {
+		mt := 1 - t
+		b0, b1, b2, b3 := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+		a0, a1 := startTangent.Mul(b1), endTangent.Mul(-b2)
+		rest := points[i].Sub(first.Mul(b0 + b1)).Sub(last.Mul(b2 + b3))
+
+		c00 += a0.Dot(a0)
+		c01 += a0.Dot(a1)
+		c11 += a1.Dot(a1)
+		x0 += a0.Dot(rest)
+		x1 += a1.Dot(rest)
+	}
+
+	length := first.Distance(last)
+	alphaStart, alphaEnd := length/3, length/3
+	if determinant := c00*c11 - c01*c01; math.Abs(determinant) > epsilon {
+		alphaStart = (x0*c11 - x1*c01) / determinant
+		alphaEnd = (c00*x1 - c01*x0) / determinant
+	}
+	// the solution is not reliable when the control points are on top of, or behind, the endpoints
+	if minAlpha := epsilon * length; alphaStart < minAlpha || alphaEnd < minAlpha {
+		alphaStart, alphaEnd = length/3, length/3
+	}
+
+	return PathData{
+		Start:   first,
+		End:     last,
+		Control: [2]vector.Vector2{first.Add(startTangent.Mul(alphaStart)), last.Sub(endTangent.Mul(alphaEnd))},
+	}
+}
+
+// fitError returns the largest squared distance between the points and the curve at their parameters,
+// and the index of the interior point where it happens
+func fitError(points []vector.Vector2, parameters []float64, curve PathData) (float64, int) {
+	maxError, split := 0.0, len(points)/2
+	for i := 1; i < len(points)-1; i++ {
+		if distance := curve.Point(parameters[i]).DistanceSqr(points[i]); distance > maxError {
+			maxError, split = distance, i
+		}
+	}
+
+	return maxError, split
+}
+
+// reparameterize returns better parameters of the points, closer to their nearest points on the curve,
+// with a step of the Newton–Raphson method
+func reparameterize(points []vector.Vector2, parameters []float64, curve PathData) []float64 {
+	improved := make([]float64, len(parameters))
+	for i, t := range parameters {
+		offset := curve.Point(t).Sub(points[i])
+		derivative, secondDerivative := curve.Derivative(t), curve.SecondDerivative(t)
+
+		improved[i] = t
+		if denominator := derivative.Dot(derivative) + offset.Dot(secondDerivative); math.Abs(denominator) > epsilon {
+			improved[i] = math.Max(0, math.Min(1, t-offset.Dot(derivative)/denominator))
+		}
+	}
+
+	return improved
+}
@@ -0,0 +1,118 @@
+package svg
+
+import (
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// checkFit checks that the curves are continuous and no further than the tolerance from the points
+// and, when smooth, that their tangents are continuous too (including between the end and the start of closed curves)
+func checkFit(t *testing.T, points []vector.Vector2, curves []PathData, tolerance float64, smooth bool) {
+	t.Helper()
+	path := Path{Data: curves}
+	for _, point := range points {
+		if distance := path.Nearest(point).Distance; distance > tolerance+1e-9 {
+			t.Errorf("the point %v is %v away from the curves, beyond the tolerance %v", point, distance, tolerance)
+		}
+	}
+
+	for i, curve := range curves {
+		next := i + 1
+		if next == len(curves) {
+			if !path.IsClosed() {
+				break
+			}
+			next = 0
+		}
+		if curve.End != curves[next].Start {
+			t.Errorf("the curve %d ends at %v, but the next one starts at %v", i, curve.End, curves[next].Start)
+		}
+		if smooth && !approxPoint(curve.Tangent(1), curves[next].Tangent(0), 1e-9) {
+			t.Errorf("the curve %d ends with the tangent %v, but the next one starts with %v", i, curve.Tangent(1), curves[next].Tangent(0))
+		}
+	}
+}
+
+func TestFitPolyline(t *testing.T) {
+	// a closed circle and an open sine wave, sampled densely
+	var circle, wave []vector.Vector2
+	for i := 0; i <= 100; i++ {
+		angle := 2 * math.Pi * float64(i%100) / 100
+		circle = append(circle, vector.Vector2{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)})
+		x := 20 * float64(i) / 100
+		wave = append(wave, vector.Vector2{X: x, Y: 3 * math.Sin(x)})
+	}
+
+	for _, tolerance := range []float64{0.1, 0.01, 0.001} {
+		for name, points := range map[string][]vector.Vector2{"circle": circle, "wave": wave} {
+			curves := FitPolyline(points, tolerance, 0)
+			checkFit(t, points, curves, tolerance, true)
+			// far fewer curves than edges, unless the tolerance is below the distance between the edges and the curve
+			if tolerance >= 0.01 && len(curves) > len(points)/2 {
+				t.Errorf("the %s is fitted by %d curves within %v", name, len(curves), tolerance)
+			}
+		}
+	}
+}
+
+func TestFitPolylineCorners(t *testing.T) {
+	// the outline of a square, with a point every unit along its sides
+	var points []vector.Vector2
+	corners := []vector.Vector2{{}, {X: 4}, {X: 4, Y: 4}, {Y: 4}}
+	for i, corner := range corners {
+		for step := 0; step < 4; step++ {
+			points = append(points, vector.LerpUnclamped(corner, corners[(i+1)%len(corners)], float64(step)/4))
+		}
+	}
+	points = append(points, points[0])
+
+	// the corners are kept and the straight sides become lines
+	curves := FitPolyline(points, 0.01, 0)
+	if len(curves) != 4 {
+		t.Fatalf("got %d curves, expected the 4 sides", len(curves))
+	}
+	for i, curve := range curves {
+		if !curve.IsLine() || curve.Start != corners[i] {
+			t.Errorf("the side %d is %v, expected a line from %v", i, curve, corners[i])
+		}
+	}
+	checkFit(t, points, curves, 0.01, false)
+
+	// with a corner angle above a right angle, the square is rounded
+	curves = FitPolyline(points, 0.5, 0.6*math.Pi)
+	checkFit(t, points, curves, 0.5, true)
+
+	// polylines without length
+	if curves := FitPolyline(nil, 0, 0); curves != nil {
+		t.Errorf("FitPolyline(nil) = %v, expected nil", curves)
+	}
+	point := vector.Vector2{X: 1, Y: 2}
+	if curves := FitPolyline([]vector.Vector2{point, point}, 0, 0); len(curves) != 1 || curves[0] != newLine(point, point) {
+		t.Errorf("FitPolyline of a point = %v, expected a line without length", curves)
+	}
+}
+
+func TestPathFit(t *testing.T) {
+	// the lines are fitted, while the curve is kept as it is
+	curve := quarterCircle(vector.Vector2{}, 10, 0)
+	path := Path{ID: "p", Data: []PathData{curve}}
+	for i := 1; i <= 20; i++ {
+		angle := math.Pi/2 + math.Pi/2*float64(i)/20
+		path.Data = append(path.Data, newLine(path.Data[len(path.Data)-1].End, vector.Vector2{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)}))
+	}
+
+	fitted := path.Fit(0.01, 0)
+	if fitted.ID != "p" || fitted.Data[0] != curve {
+		t.Fatalf("Fit() = %v, expected the curve to be kept", fitted)
+	}
+	var points []vector.Vector2
+	for _, data := range path.Data[1:] {
+		points = append(points, data.End)
+	}
+	checkFit(t, points, fitted.Data[1:], 0.01, true)
+	if len(fitted.Data) > 10 {
+		t.Errorf("got %d curves, expected the lines to be fitted by a few curves", len(fitted.Data))
+	}
+}
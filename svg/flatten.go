@@ -0,0 +1,169 @@
+package svg
+
+import (
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// flattening settings
+const (
+	// default maximum distance between a curve and its flattened edges
+	defaultFlatteningTolerance = 0.1
+	// maximum number of times a curve is subdivided while flattening
+	maxFlatteningDepth = 16
+)
+
+// newLine creates and returns a straight line from start to end
+// like the parsed lines, both control points are placed halfway between the endpoints
+func newLine(start, end vector.Vector2) PathData {
+	middle := vector.Vector2{X: 0.5 * (start.X + end.X), Y: 0.5 * (start.Y + end.Y)}
+	return PathData{
+		Start:   start,
+		End:     end,
+		Control: [2]vector.Vector2{middle, middle},
+	}
+}
+
+// IsLine checks if the path data is a straight line, i.e. both control points lie on the segment between the endpoints
+func (p PathData) IsLine() bool {
+	return p.flatness() < epsilon
+}
+
+// flatness returns the maximum distance between the control points and the segment between the endpoints,
+// which bounds the distance between the curve and that segment
+func (p PathData) flatness() float64 {
+	return mathf.Max(
+		distanceToSegment(p.Control[0], p.Start, p.End),
+		distanceToSegment(p.Control[1], p.Start, p.End),
+	)
+}
+
+// Flatten approximates the curve by a polyline, whose points are no further than the tolerance from the curve
+// the start point is not included, and straight lines return only their end point
+// zero or less uses the default tolerance (0.1)
+func (p PathData) Flatten(tolerance float64) []vector.Vector2 {
+	if tolerance <= 0 {
+		tolerance = defaultFlatteningTolerance
+	}
+
+	return p.flatten(tolerance, 0, nil, -1)
+}
+
+// flatten recursively subdivides the curve until it is within the tolerance of its chord
+// it stops as soon as the polyline has the given maximum number of points, where a negative maximum disables it
+func (p PathData) flatten(tolerance float64, depth int, points []vector.Vector2, maxPoints int) []vector.Vector2 {
+	if maxPoints >= 0 && len(points) >= maxPoints {
+		return points
+	}
+	if depth >= maxFlatteningDepth || p.flatness() <= tolerance {
+		return append(points, p.End)
+	}
+
+	left, right := p.Split(0.5)
+	points = left.flatten(tolerance, depth+1, points, maxPoints)
+
+	return right.flatten(tolerance, depth+1, points, maxPoints)
+}
+
+// IsClosed checks if the path ends where it starts
+func (p Path) IsClosed() bool {
+	if len(p.Data) == 0 {
+		return false
+	}
+	return p.Data[0].Start.DistanceSqr(p.Data[len(p.Data)-1].End) < epsilon*epsilon
+}
+
+// Subpaths splits the path into its continuous subpaths, which start wherever a segment
+// does not start at the end of the previous one (e.g. after a "MoveTo" command)
+func (p Path) Subpaths() []Path {
+	var subpaths []Path
+	start := 0
+	for i := 1; i <= len(p.Data); i++ {
+		if i < len(p.Data) && p.Data[i].Start.DistanceSqr(p.Data[i-1].End) < epsilon*epsilon {
+			continue
+		}
+
+		subpath := p
+		subpath.Data = p.Data[start:i]
+		subpaths = append(subpaths, subpath)
+		start = i
+	}
+
+	return subpaths
+}
+
+// Flatten returns a copy of the path whose curves are replaced by straight lines, no further than
+// the tolerance from the curves; straight lines are kept as single lines
+// zero or less uses the default tolerance (0.1)
+func (p Path) Flatten(tolerance float64) Path {
+	flattened, _ := p.flatten(tolerance, -1)
+	return flattened
+}
+
+// flatten returns a copy of the path whose curves are replaced by straight lines, like Flatten, stopping as soon as
+// it has more than the given maximum number of lines, in which case false is returned; a negative maximum disables it
+func (p Path) flatten(tolerance float64, maxLines int) (Path, bool) {
+	if tolerance <= 0 {
+		tolerance = defaultFlatteningTolerance
+	}
+
+	var data []PathData
+	for _, d := range p.Data {
+		// one more point than allowed is enough to know that the limit is exceeded
+		maxPoints := -1
+		if maxLines >= 0 {
+			maxPoints = maxLines - len(data) + 1
+		}
+
+		start := d.Start
+		for _, point := range d.flatten(tolerance, 0, nil, maxPoints) {
+			data = append(data, newLine(start, point))
+			start = point
+		}
+		if maxLines >= 0 && len(data) > maxLines {
+			return Path{}, false
+		}
+	}
+	p.Data = data
+
+	return p, true
+}
+
+// Polylines approximates every subpath by a polyline, whose points are no further than the tolerance from the path
+// closed subpaths end with their start point
+// zero or less uses the default tolerance (0.1)
+func (p Path) Polylines(tolerance float64) [][]vector.Vector2 {
+	var polylines [][]vector.Vector2
+	for _, subpath := range p.Subpaths() {
+		polyline := []vector.Vector2{subpath.Data[0].Start}
+		for _, d := range subpath.Data {
+			polyline = append(polyline, d.Flatten(tolerance)...)
+		}
+		polylines = append(polylines, polyline)
+	}
+
+	return polylines
+}
+
+// distanceToSegment returns the distance between the point and the segment from a to b
+func distanceToSegment(point, a, b vector.Vector2) float64 {
+	return point.Distance(closestPointOnSegment(point, a, b))
+}
+
+// closestPointOnSegment returns the point of the segment from a to b closest to the given point
+func closestPointOnSegment(point, a, b vector.Vector2) vector.Vector2 {
+	ab := b.Sub(a)
+	length := ab.MagnitudeSqr()
+	if length < epsilon*epsilon {
+		return a
+	}
+
+	t := point.Sub(a).Dot(ab) / length
+	if t <= 0 {
+		return a
+	} else if t >= 1 {
+		return b
+	}
+
+	return a.Add(ab.Mul(t))
+}
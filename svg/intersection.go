@@ -0,0 +1,418 @@
+package svg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// intersection settings
+const (
+	// flatness, relative to the size of the curves, below which subdivided parts are intersected as lines
+	intersectionFlatness = 1e-4
+	// maximum number of times the curves are subdivided while looking for intersections
+	maxIntersectionDepth = 32
+	// maximum number of subdivisions, which bounds the work done on overlapping curves
+	maxIntersectionSteps = 1 << 14
+	// distance, relative to the size of the curves, within which a refined point is accepted as an intersection
+	intersectionTolerance = 1e-7
+	// parameter distance within which two intersections are considered the same
+	intersectionParameterTolerance = 1e-6
+)
+
+// Intersection represents a point where two curves (path data) meet
+type Intersection struct {
+	// Point contains the point of intersection
+	Point vector.Vector2
+	// T1 and T2 contain the parameters of the point in the first and second curve, respectively
+	T1, T2 float64
+}
+
+// PathIntersection represents a point where two paths meet
+type PathIntersection struct {
+	// Point contains the point of intersection
+	Point vector.Vector2
+	// Segment1 and T1 contain the index of the segment (path data) and its parameter in the first path
+	Segment1 int
+	T1       float64
+	// Segment2 and T2 contain the index of the segment (path data) and its parameter in the second path
+	Segment2 int
+	T2       float64
+}
+
+// Intersections returns the points where the curve meets the other one
+// collinear overlapping lines have infinitely many common points, so none are returned for them
+func (p PathData) Intersections(other PathData) []Intersection {
+	pLine, otherLine := p.IsLine(), other.IsLine()
+	switch {
+	case pLine && otherLine:
+		return lineLineIntersections(p, other)
+	case pLine:
+		return lineCurveIntersections(p, other)
+	case otherLine:
+		return swapIntersections(lineCurveIntersections(other, p))
+	}
+
+	return curveCurveIntersections(p, other)
+}
+
+// swapIntersections swaps the parameters of the curves of the given intersections, returning them
+func swapIntersections(intersections []Intersection) []Intersection {
+	for i := range intersections {
+		intersections[i].T1, intersections[i].T2 = intersections[i].T2, intersections[i].T1
+	}
+	return intersections
+}
+
+// lineLineIntersections returns the intersection of two straight lines
+func lineLineIntersections(a, b PathData) []Intersection {
+	u, v, ok := segmentIntersection(a.Start, a.End, b.Start, b.End)
+	if !ok {
+		return nil
+	}
+
+	point := vector.LerpUnclamped(a.Start, a.End, u)
+	return []Intersection{{
+		Point: point,
+		T1:    a.lineParameter(u),
+		T2:    b.lineParameter(v),
+	}}
+}
+
+// segmentIntersection returns the fractions, along each segment, of the intersection of the segments a0-a1 and b0-b1
+// parallel segments do not intersect
+func segmentIntersection(a0, a1, b0, b1 vector.Vector2) (float64, float64, bool) {
+	da := a1.Sub(a0)
+	db := b1.Sub(b0)
+	denominator := da.Cross(db)
+	if math.Abs(denominator) < epsilon*math.Max(1, da.Magnitude()*db.Magnitude()) {
+		return 0, 0, false
+	}
+
+	offset := b0.Sub(a0)
+	u := offset.Cross(db) / denominator
+	v := offset.Cross(da) / denominator
+	// accepts the intersections slightly outside, due to rounding errors
+	const tolerance = 1e-12
+	if u < -tolerance || u > 1+tolerance || v < -tolerance || v > 1+tolerance {
+		return 0, 0, false
+	}
+
+	return mathf.Clamp(u, 0, 1), mathf.Clamp(v, 0, 1), true
+}
+
+// lineParameter converts the fraction of a straight line, between its endpoints, into the parameter of the curve
+// since the control points of a line are not necessarily evenly spaced, the parameter is not linear with the fraction
+func (p PathData) lineParameter(fraction float64) float64 {
+	chord := p.End.Sub(p.Start)
+	length := chord.MagnitudeSqr()
+	if length < epsilon*epsilon || fraction <= 0 || fraction >= 1 {
+		return mathf.Clamp(fraction, 0, 1)
+	}
+
+	// the fraction of each point along the chord is a cubic polynomial in the Bernstein basis
+	project := func(point vector.Vector2) float64 {
+		return point.Sub(p.Start).Dot(chord) / length
+	}
+	a, b, c, d := bernsteinToPower(0, project(p.Control[0]), project(p.Control[1]), 1)
+
+	// the root nearest to the range [0, 1] is the one of the line
+	best, bestDistance := fraction, math.Inf(1)
+	for _, root := range solveCubic(a, b, c, d-fraction) {
+		distance := math.Abs(root - mathf.Clamp(root, 0, 1))
+		if distance < bestDistance {
+			best, bestDistance = mathf.Clamp(root, 0, 1), distance
+		}
+	}
+
+	return best
+}
+
+// lineCurveIntersections returns the intersections of a straight line with a curve
+func lineCurveIntersections(line, curve PathData) []Intersection {
+	chord := line.End.Sub(line.Start)
+	length := chord.MagnitudeSqr()
+	if length < epsilon*epsilon {
+		return nil
+	}
+
+	// signed distances of the curve to the line, as a cubic polynomial in the Bernstein basis
+	distance := func(point vector.Vector2) float64 {
+		return chord.Cross(point.Sub(line.Start))
+	}
+	a, b, c, d := bernsteinToPower(distance(curve.Start), distance(curve.Control[0]), distance(curve.Control[1]), distance(curve.End))
+
+	var intersections []Intersection
+	for _, t := range solveCubic(a, b, c, d) {
+		const tolerance = 1e-12
+		if t < -tolerance || t > 1+tolerance {
+			continue
+		}
+		t = mathf.Clamp(t, 0, 1)
+
+		// fraction of the point along the line
+		point := curve.Point(t)
+		u := point.Sub(line.Start).Dot(chord) / length
+		if u < -tolerance || u > 1+tolerance {
+			continue
+		}
+
+		intersections = appendIntersection(intersections, Intersection{
+			Point: point,
+			T1:    line.lineParameter(mathf.Clamp(u, 0, 1)),
+			T2:    t,
+		})
+	}
+
+	return intersections
+}
+
+// curveIntersectionSearch holds the state of the search for the intersections of two curves
+type curveIntersectionSearch struct {
+	a, b          PathData
+	flatness      float64
+	tolerance     float64
+	steps         int
+	intersections []Intersection
+}
+
+// curveCurveIntersections returns the intersections of two curves, subdividing both while their boxes overlap
+func curveCurveIntersections(a, b PathData) []Intersection {
+	size := a.controlBox().Union(b.controlBox()).Size().Magnitude()
+	search := curveIntersectionSearch{
+		a:         a,
+		b:         b,
+		flatness:  intersectionFlatness*size + epsilon,
+		tolerance: intersectionTolerance*size + epsilon,
+	}
+	search.subdivide(a, 0, 1, b, 0, 1, 0)
+
+	return search.intersections
+}
+
+// subdivide looks for intersections between the parts of the curves in the ranges [a0, a1] and [b0, b1]
+func (s *curveIntersectionSearch) subdivide(a PathData, a0, a1 float64, b PathData, b0, b1 float64, depth int) {
+	s.steps++
+	if s.steps > maxIntersectionSteps || !a.controlBox().overlaps(b.controlBox(), s.tolerance) {
+		return
+	}
+
+	aFlat, bFlat := a.flatness() <= s.flatness, b.flatness() <= s.flatness
+	if (aFlat && bFlat) || depth >= maxIntersectionDepth {
+		// initial guess from the intersection of the chords, refined on the whole curves
+		u, v, ok := segmentIntersection(a.Start, a.End, b.Start, b.End)
+		if !ok {
+			// parallel chords; their midpoints are the best guess
+			u, v = 0.5, 0.5
+		}
+		s.refine(a0+u*(a1-a0), b0+v*(b1-b0))
+
+		return
+	}
+
+	// subdivides the curves that are not flat yet
+	aParts, aRanges := []PathData{a}, [][2]float64{{a0, a1}}
+	if !aFlat {
+		left, right := a.Split(0.5)
+		middle := 0.5 * (a0 + a1)
+		aParts, aRanges = []PathData{left, right}, [][2]float64{{a0, middle}, {middle, a1}}
+	}
+	bParts, bRanges := []PathData{b}, [][2]float64{{b0, b1}}
+	if !bFlat {
+		left, right := b.Split(0.5)
+		middle := 0.5 * (b0 + b1)
+		bParts, bRanges = []PathData{left, right}, [][2]float64{{b0, middle}, {middle, b1}}
+	}
+
+	for i, aPart := range aParts {
+		for j, bPart := range bParts {
+			s.subdivide(aPart, aRanges[i][0], aRanges[i][1], bPart, bRanges[j][0], bRanges[j][1], depth+1)
+		}
+	}
+}
+
+// refine improves the given parameters with Newton's method, solving a(s) = b(t),
+// and keeps the intersection if the curves meet there
+func (s *curveIntersectionSearch) refine(t1, t2 float64) {
+	for i := 0; i < maxNewtonIterations; i++ {
+		difference := s.a.Point(t1).Sub(s.b.Point(t2))
+		if difference.Magnitude() < epsilon {
+			break
+		}
+
+		da := s.a.Derivative(t1)
+		db := s.b.Derivative(t2)
+		determinant := da.Cross(db)
+		// tangent curves; the current guess is kept
+		if math.Abs(determinant) < epsilon {
+			break
+		}
+
+		t1 = mathf.Clamp(t1-difference.Cross(db)/determinant, 0, 1)
+		t2 = mathf.Clamp(t2-difference.Cross(da)/determinant, 0, 1)
+	}
+
+	pointA, pointB := s.a.Point(t1), s.b.Point(t2)
+	if pointA.Distance(pointB) > s.tolerance {
+		return
+	}
+
+	s.intersections = appendIntersection(s.intersections, Intersection{
+		Point: vector.LerpUnclamped(pointA, pointB, 0.5),
+		T1:    t1,
+		T2:    t2,
+	})
+}
+
+// appendIntersection adds the intersection to the given ones, unless it is already there
+func appendIntersection(intersections []Intersection, intersection Intersection) []Intersection {
+	for _, existing := range intersections {
+		if math.Abs(existing.T1-intersection.T1) < intersectionParameterTolerance &&
+			math.Abs(existing.T2-intersection.T2) < intersectionParameterTolerance {
+			return intersections
+		}
+	}
+
+	return append(intersections, intersection)
+}
+
+// overlaps checks if the box overlaps the other one, within the given tolerance
+func (b Box) overlaps(other Box, tolerance float64) bool {
+	return b.Min.X <= other.Max.X+tolerance && other.Min.X <= b.Max.X+tolerance &&
+		b.Min.Y <= other.Max.Y+tolerance && other.Min.Y <= b.Max.Y+tolerance
+}
+
+// selfIntersections returns the points where the curve crosses itself, with T1 lower than T2
+func (p PathData) selfIntersections() []Intersection {
+	if p.IsLine() {
+		return nil
+	}
+
+	// a curve that is monotonic in both axes cannot cross itself, so it is split at its extremes
+	bounds := append([]float64{0}, sortedParameters(p.extremes())...)
+	bounds = append(bounds, 1)
+
+	var intersections []Intersection
+	for i := 0; i+1 < len(bounds); i++ {
+		for j := i + 1; j+1 < len(bounds); j++ {
+			a := p.SubCurve(bounds[i], bounds[i+1])
+			b := p.SubCurve(bounds[j], bounds[j+1])
+			for _, intersection := range a.Intersections(b) {
+				t1 := bounds[i] + intersection.T1*(bounds[i+1]-bounds[i])
+				t2 := bounds[j] + intersection.T2*(bounds[j+1]-bounds[j])
+				// the adjacent parts share an endpoint, which is not a crossing
+				if t2-t1 < intersectionParameterTolerance {
+					continue
+				}
+				intersections = appendIntersection(intersections, Intersection{Point: intersection.Point, T1: t1, T2: t2})
+			}
+		}
+	}
+
+	return intersections
+}
+
+// Intersections returns the points where the path meets the other one
+func (p Path) Intersections(other Path) []PathIntersection {
+	var intersections []PathIntersection
+	for i, a := range p.Data {
+		aBox := a.controlBox()
+		for j, b := range other.Data {
+			if !aBox.overlaps(b.controlBox(), epsilon) {
+				continue
+			}
+
+			for _, intersection := range a.Intersections(b) {
+				intersections = append(intersections, PathIntersection{
+					Point:    intersection.Point,
+					Segment1: i,
+					T1:       intersection.T1,
+					Segment2: j,
+					T2:       intersection.T2,
+				})
+			}
+		}
+	}
+
+	return intersections
+}
+
+// SelfIntersections returns the points where the path crosses itself, with Segment1 and T1 before Segment2 and T2
+// the endpoints shared by consecutive segments (including the closing point of closed subpaths) are not crossings
+func (p Path) SelfIntersections() []PathIntersection {
+	var intersections []PathIntersection
+	for i, a := range p.Data {
+		for _, intersection := range a.selfIntersections() {
+			intersections = append(intersections, PathIntersection{
+				Point:    intersection.Point,
+				Segment1: i,
+				T1:       intersection.T1,
+				Segment2: i,
+				T2:       intersection.T2,
+			})
+		}
+
+		aBox := a.controlBox()
+		for j := i + 1; j < len(p.Data); j++ {
+			b := p.Data[j]
+			if !aBox.overlaps(b.controlBox(), epsilon) {
+				continue
+			}
+
+			for _, intersection := range a.Intersections(b) {
+				if p.isJoint(i, intersection.T1, j, intersection.T2) {
+					continue
+				}
+				intersections = append(intersections, PathIntersection{
+					Point:    intersection.Point,
+					Segment1: i,
+					T1:       intersection.T1,
+					Segment2: j,
+					T2:       intersection.T2,
+				})
+			}
+		}
+	}
+
+	return intersections
+}
+
+// isJoint checks if the intersection between the segments i and j, at the given parameters,
+// is the endpoint they share as consecutive segments
+func (p Path) isJoint(i int, t1 float64, j int, t2 float64) bool {
+	const tolerance = intersectionParameterTolerance
+	// the end of a segment connected to the start of the next one
+	if j == i+1 && t1 > 1-tolerance && t2 < tolerance && p.Data[i].End.DistanceSqr(p.Data[j].Start) < epsilon*epsilon {
+		return true
+	}
+
+	// the end of the last segment of a closed subpath connected to its start
+	if t1 < tolerance && t2 > 1-tolerance && p.Data[j].End.DistanceSqr(p.Data[i].Start) < epsilon*epsilon {
+		// every segment in between must be connected
+		for k := i + 1; k <= j; k++ {
+			if p.Data[k].Start.DistanceSqr(p.Data[k-1].End) >= epsilon*epsilon {
+				return false
+			}
+		}
+		return i == 0 || p.Data[i].Start.DistanceSqr(p.Data[i-1].End) >= epsilon*epsilon
+	}
+
+	return false
+}
+
+// sortedParameters returns the given parameters sorted, without duplicates
+func sortedParameters(parameters []float64) []float64 {
+	sorted := append([]float64(nil), parameters...)
+	sort.Float64s(sorted)
+
+	var unique []float64
+	for _, t := range sorted {
+		if len(unique) == 0 || t-unique[len(unique)-1] > intersectionParameterTolerance {
+			unique = append(unique, t)
+		}
+	}
+
+	return unique
+}
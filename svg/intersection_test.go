@@ -0,0 +1,154 @@
+package svg
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// checkIntersections checks that the intersections of the curves are at the given points, in any order,
+// and that their parameters are at the same points of both curves
+func checkIntersections(t *testing.T, a, b PathData, intersections []Intersection, points []vector.Vector2, tolerance float64) {
+	t.Helper()
+	if len(intersections) != len(points) {
+		t.Fatalf("got %d intersections %v, expected %v", len(intersections), intersections, points)
+	}
+	for _, intersection := range intersections {
+		if !approxPoint(a.Point(intersection.T1), intersection.Point, 1e-6) || !approxPoint(b.Point(intersection.T2), intersection.Point, 1e-6) {
+			t.Errorf("the parameters of %+v are not at its point", intersection)
+		}
+		found := false
+		for _, point := range points {
+			found = found || approxPoint(intersection.Point, point, tolerance)
+		}
+		if !found {
+			t.Errorf("unexpected intersection %+v, expected one of %v", intersection, points)
+		}
+	}
+}
+
+func TestPathDataIntersections(t *testing.T) {
+	// the cubic approximation of a circle is off by about 3e-4 of the radius
+	const circleTolerance = 1e-3
+	halfSqrt3 := math.Sqrt(3) / 2
+	unitQuarter := quarterCircle(vector.Vector2{}, 1, 0)
+
+	tests := []struct {
+		name      string
+		a, b      PathData
+		points    []vector.Vector2
+		tolerance float64
+	}{
+		{"crossing lines", newLine(vector.Vector2{}, vector.Vector2{X: 2, Y: 2}), newLine(vector.Vector2{Y: 2}, vector.Vector2{X: 2}), []vector.Vector2{{X: 1, Y: 1}}, 1e-9},
+		{"lines meeting at an endpoint", newLine(vector.Vector2{}, vector.Vector2{X: 2}), newLine(vector.Vector2{X: 2}, vector.Vector2{X: 3, Y: 4}), []vector.Vector2{{X: 2}}, 1e-9},
+		{"parallel lines", newLine(vector.Vector2{}, vector.Vector2{X: 2}), newLine(vector.Vector2{Y: 1}, vector.Vector2{X: 2, Y: 1}), nil, 0},
+		{"collinear lines", newLine(vector.Vector2{}, vector.Vector2{X: 2}), newLine(vector.Vector2{X: 1}, vector.Vector2{X: 3}), nil, 0},
+		{"disjoint lines", newLine(vector.Vector2{}, vector.Vector2{X: 1, Y: 1}), newLine(vector.Vector2{X: 3}, vector.Vector2{X: 2, Y: 5}), nil, 0},
+		{"line and quarter circle", newLine(vector.Vector2{X: -1, Y: 0.5}, vector.Vector2{X: 2, Y: 0.5}), unitQuarter, []vector.Vector2{{X: halfSqrt3, Y: 0.5}}, circleTolerance},
+		{"quarter circle and line", unitQuarter, newLine(vector.Vector2{X: 0.5, Y: -1}, vector.Vector2{X: 0.5, Y: 2}), []vector.Vector2{{X: 0.5, Y: halfSqrt3}}, circleTolerance},
+		{"line missing the quarter circle", newLine(vector.Vector2{}, vector.Vector2{X: 0.5, Y: 0.5}), unitQuarter, nil, 0},
+		// the line goes through the point where the loop crosses itself, which is on both of its branches
+		{
+			"line across the loop",
+			newLine(vector.Vector2{X: -1, Y: 0.3}, vector.Vector2{X: 2, Y: 0.3}),
+			PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 2, Y: 1}, {X: -1, Y: 1}}},
+			[]vector.Vector2{{X: 0.5, Y: 0.3}, {X: 0.5, Y: 0.3}}, 1e-6,
+		},
+		{"quarter circles", unitQuarter, quarterCircle(vector.Vector2{X: 1}, 1, math.Pi/2), []vector.Vector2{{X: 0.5, Y: halfSqrt3}}, circleTolerance},
+		{"disjoint curves", unitQuarter, quarterCircle(vector.Vector2{X: 5}, 1, 0), nil, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkIntersections(t, test.a, test.b, test.a.Intersections(test.b), test.points, test.tolerance)
+			// the intersections do not depend on the order of the curves
+			checkIntersections(t, test.b, test.a, test.b.Intersections(test.a), test.points, test.tolerance)
+		})
+	}
+}
+
+func TestPathDataSelfIntersections(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   PathData
+		points []vector.Vector2
+	}{
+		// the loop is symmetric, so it crosses itself where y = 3t(1-t) at the parameters t and 1-t
+		{"loop", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 2, Y: 1}, {X: -1, Y: 1}}}, []vector.Vector2{{X: 0.5, Y: 0.3}}},
+		{"cusp", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 1, Y: 1}, {Y: 1}}}, nil},
+		{"quarter circle", quarterCircle(vector.Vector2{}, 1, 0), nil},
+		{"line", newLine(vector.Vector2{}, vector.Vector2{X: 1, Y: 1}), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			intersections := test.data.selfIntersections()
+			checkIntersections(t, test.data, test.data, intersections, test.points, 1e-6)
+			for _, intersection := range intersections {
+				if intersection.T2-intersection.T1 < intersectionParameterTolerance {
+					t.Errorf("%+v is not a crossing of two different parameters in order", intersection)
+				}
+			}
+		})
+	}
+
+	loop := tests[0].data.selfIntersections()
+	if t1 := (1 - math.Sqrt(0.6)) / 2; len(loop) == 1 && (!approxEqual(loop[0].T1, t1, 1e-6) || !approxEqual(loop[0].T2, 1-t1, 1e-6)) {
+		t.Errorf("the loop crosses itself at the parameters %v and %v, expected %v and %v", loop[0].T1, loop[0].T2, t1, 1-t1)
+	}
+}
+
+func TestPathIntersections(t *testing.T) {
+	circles := circle(vector.Vector2{}, 1).Intersections(circle(vector.Vector2{X: 1}, 1))
+	if len(circles) != 2 {
+		t.Fatalf("got %d intersections of the circles %v, expected 2", len(circles), circles)
+	}
+	for _, intersection := range circles {
+		if !approxEqual(intersection.Point.X, 0.5, 1e-3) || !approxEqual(math.Abs(intersection.Point.Y), math.Sqrt(3)/2, 1e-3) {
+			t.Errorf("unexpected intersection of the circles %+v", intersection)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		path   Path
+		points []vector.Vector2
+	}{
+		// the joints between consecutive segments are not crossings
+		{"square", square(0, 0, 2), nil},
+		{"bow tie", polygonPath(vector.Vector2{}, vector.Vector2{X: 2, Y: 2}, vector.Vector2{X: 2}, vector.Vector2{Y: 2}), []vector.Vector2{{X: 1, Y: 1}}},
+		{"circle", circle(vector.Vector2{}, 1), nil},
+		{"loop", Path{Data: []PathData{
+			newLine(vector.Vector2{X: -1}, vector.Vector2{}),
+			{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 2, Y: 1}, {X: -1, Y: 1}}},
+		}}, []vector.Vector2{{X: 0.5, Y: 0.3}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			intersections := test.path.SelfIntersections()
+			if len(intersections) != len(test.points) {
+				t.Fatalf("got %d self-intersections %v, expected %v", len(intersections), intersections, test.points)
+			}
+			for i, intersection := range intersections {
+				if !approxPoint(intersection.Point, test.points[i], 1e-6) ||
+					intersection.Segment1 > intersection.Segment2 ||
+					(intersection.Segment1 == intersection.Segment2 && intersection.T1 >= intersection.T2) {
+					t.Errorf("unexpected self-intersection %+v, expected %v", intersection, test.points[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSortedParameters(t *testing.T) {
+	parameters := []float64{0.5, 0.1, 0.5 + intersectionParameterTolerance/2, 0.9, 0.3, 0.1}
+	if sorted := sortedParameters(parameters); !reflect.DeepEqual(sorted, []float64{0.1, 0.3, 0.5, 0.9}) {
+		t.Errorf("sortedParameters(%v) = %v, expected [0.1 0.3 0.5 0.9]", parameters, sorted)
+	}
+	if parameters[0] != 0.5 {
+		t.Errorf("sortedParameters modified its argument to %v", parameters)
+	}
+	if sorted := sortedParameters(nil); len(sorted) != 0 {
+		t.Errorf("sortedParameters(nil) = %v, expected none", sorted)
+	}
+}
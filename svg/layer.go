@@ -0,0 +1,45 @@
+package svg
+
+// For more information on Inkscape layers:
+// - https://inkscape.org/doc/inkscape-man.html
+
+import "strings"
+
+// Inkscape layer values
+const (
+	layerGroupMode = "layer"
+	layerSeparator = "/"
+)
+
+// isLayer checks if the given element is an Inkscape layer
+func isLayer(e element) bool {
+	return e.XMLName.Local == groupElementTag && e.GroupMode() == layerGroupMode
+}
+
+// layerName returns the human readable name of a layer
+// falls back to its identifier when the layer has no label
+func layerName(e element) string {
+	if label := e.Label(); label != "" {
+		return label
+	}
+	return e.ID()
+}
+
+// joinLayer appends the given layer name to the parent layer path
+func joinLayer(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + layerSeparator + name
+}
+
+// matchLayer checks if a layer, given its name and path, matches any of the given filters
+func matchLayer(filters []string, name, layerPath string) bool {
+	for _, filter := range filters {
+		filter = strings.Trim(filter, layerSeparator)
+		if filter == name || filter == layerPath {
+			return true
+		}
+	}
+	return false
+}
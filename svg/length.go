@@ -0,0 +1,271 @@
+package svg
+
+// For more information on Gauss–Legendre quadrature:
+// - https://en.wikipedia.org/wiki/Gauss%E2%80%93Legendre_quadrature
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// arc length settings
+const (
+	// relative tolerance of the adaptive quadrature
+	lengthTolerance = 1e-10
+	// maximum number of times the adaptive quadrature halves an interval
+	maxLengthDepth = 24
+	// maximum number of times an arc length table interval is halved
+	maxTableDepth = 16
+	// maximum number of refinement iterations of an arc length lookup
+	maxLookupIterations = 16
+	// default precision of an arc length table
+	defaultArcLengthPrecision = 1e-3
+)
+
+// 5-point Gauss–Legendre abscissae and weights, in the range [-1, 1]
+var (
+	gaussAbscissae = [...]float64{
+		0,
+		-0.5384693101056830910363144, 0.5384693101056830910363144,
+		-0.9061798459386639927976269, 0.9061798459386639927976269,
+	}
+	gaussWeights = [...]float64{
+		0.5688888888888888888888889,
+		0.4786286704993664680412915, 0.4786286704993664680412915,
+		0.2369268850561890875142640, 0.2369268850561890875142640,
+	}
+)
+
+// gaussLegendre integrates the given function in the range [a, b] with a 5-point Gauss–Legendre rule
+func gaussLegendre(f func(float64) float64, a, b float64) float64 {
+	half := 0.5 * (b - a)
+	middle := 0.5 * (a + b)
+
+	var sum float64
+	for i, x := range gaussAbscissae {
+		sum += gaussWeights[i] * f(middle+half*x)
+	}
+
+	return half * sum
+}
+
+// adaptiveGaussLegendre integrates the given function in the range [a, b], halving the range until
+// the estimate converges
+func adaptiveGaussLegendre(f func(float64) float64, a, b, whole float64, depth int) float64 {
+	middle := 0.5 * (a + b)
+	left := gaussLegendre(f, a, middle)
+	right := gaussLegendre(f, middle, b)
+
+	if depth >= maxLengthDepth || math.Abs(left+right-whole) <= lengthTolerance*math.Max(1, math.Abs(whole)) {
+		return left + right
+	}
+
+	return adaptiveGaussLegendre(f, a, middle, left, depth+1) + adaptiveGaussLegendre(f, middle, b, right, depth+1)
+}
+
+// Length returns the arc length of the curve
+func (p PathData) Length() float64 {
+	return p.LengthBetween(0, 1)
+}
+
+// LengthBetween returns the arc length of the curve between the parameters t0 and t1
+func (p PathData) LengthBetween(t0, t1 float64) float64 {
+	t0, t1 = mathf.Clamp(t0, 0, 1), mathf.Clamp(t1, 0, 1)
+	if t1 <= t0 {
+		return 0
+	}
+
+	speed := func(t float64) float64 {
+		return p.Derivative(t).Magnitude()
+	}
+
+	return adaptiveGaussLegendre(speed, t0, t1, gaussLegendre(speed, t0, t1), 0)
+}
+
+// Length returns the arc length of the path
+func (p Path) Length() float64 {
+	var length float64
+	for _, data := range p.Data {
+		length += data.Length()
+	}
+
+	return length
+}
+
+// PointAtDistance returns the position of the path at the given distance along it
+// it builds an arc length table on every call, so ArcLengthTable should be preferred for repeated queries
+func (p Path) PointAtDistance(distance float64) vector.Vector2 {
+	return NewArcLengthTable(p, defaultArcLengthPrecision).PointAtDistance(distance)
+}
+
+// TangentAtDistance returns the unit tangent of the path at the given distance along it
+// it builds an arc length table on every call, so ArcLengthTable should be preferred for repeated queries
+func (p Path) TangentAtDistance(distance float64) vector.Vector2 {
+	return NewArcLengthTable(p, defaultArcLengthPrecision).TangentAtDistance(distance)
+}
+
+// arcLengthSample represents a sample of an arc length table
+type arcLengthSample struct {
+	// Distance contains the distance along the path up to the sample
+	Distance float64
+	// Segment contains the index of the segment (path data) of the sample
+	Segment int
+	// T contains the parameter of the sample in its segment
+	T float64
+}
+
+// ArcLengthTable maps distances along a path to segments (path data) and their parameters,
+// allowing a path to be followed at constant speed
+type ArcLengthTable struct {
+	path      Path
+	precision float64
+	samples   []arcLengthSample
+}
+
+// NewArcLengthTable creates and returns the arc length table of the given path
+// the precision is the maximum difference between the arc and the chord of the sampled intervals,
+// and the maximum error of the distance of a lookup; zero or less uses the default (1e-3)
+func NewArcLengthTable(path Path, precision float64) ArcLengthTable {
+	if precision <= 0 {
+		precision = defaultArcLengthPrecision
+	}
+
+	table := ArcLengthTable{
+		path:      path,
+		precision: precision,
+	}
+	var distance float64
+	for i, data := range path.Data {
+		table.samples = append(table.samples, arcLengthSample{Distance: distance, Segment: i})
+		distance = table.sample(i, data, 0, 1, distance, 0)
+	}
+
+	return table
+}
+
+// sample adds the samples of the segment in the range [t0, t1], halving the range while the curve
+// deviates from its chord, and returns the distance at t1
+func (a *ArcLengthTable) sample(segment int, data PathData, t0, t1, distance float64, depth int) float64 {
+	length := data.LengthBetween(t0, t1)
+	chord := data.Point(t0).Distance(data.Point(t1))
+	if depth < maxTableDepth && length-chord > a.precision {
+		middle := 0.5 * (t0 + t1)
+		distance = a.sample(segment, data, t0, middle, distance, depth+1)
+		return a.sample(segment, data, middle, t1, distance, depth+1)
+	}
+
+	distance += length
+	a.samples = append(a.samples, arcLengthSample{Distance: distance, Segment: segment, T: t1})
+
+	return distance
+}
+
+// Path returns the path of the table
+func (a ArcLengthTable) Path() Path {
+	return a.path
+}
+
+// Length returns the arc length of the path
+func (a ArcLengthTable) Length() float64 {
+	if len(a.samples) == 0 {
+		return 0
+	}
+	return a.samples[len(a.samples)-1].Distance
+}
+
+// Lookup returns the index of the segment (path data) and its parameter at the given distance along the path
+// the distance is clamped to the length of the path; an empty path returns a negative index
+func (a ArcLengthTable) Lookup(distance float64) (int, float64) {
+	if len(a.samples) == 0 {
+		return -1, 0
+	}
+
+	distance = mathf.Clamp(distance, 0, a.Length())
+	// first sample at or beyond the distance
+	i := sort.Search(len(a.samples), func(i int) bool {
+		return a.samples[i].Distance >= distance
+	})
+	if i == 0 {
+		return a.samples[0].Segment, a.samples[0].T
+	}
+
+	// the samples enclosing the distance always belong to the same segment,
+	// since every segment starts with a sample at the same distance as the end of the previous one
+	previous, next := a.samples[i-1], a.samples[i]
+	if next.Distance-previous.Distance < epsilon {
+		return next.Segment, next.T
+	}
+	data := a.path.Data[next.Segment]
+
+	// initial guess by linear interpolation, refined with Newton's method within the interval
+	low, high := previous.T, next.T
+	ratio := (distance - previous.Distance) / (next.Distance - previous.Distance)
+	t := mathf.Lerp(low, high, ratio)
+	for j := 0; j < maxLookupIterations; j++ {
+		difference := previous.Distance + data.LengthBetween(previous.T, t) - distance
+		if math.Abs(difference) <= a.precision*1e-3 {
+			break
+		}
+
+		// keeps the interval bracketing the solution
+		if difference > 0 {
+			high = t
+		} else {
+			low = t
+		}
+
+		speed := data.Derivative(t).Magnitude()
+		guess := t - difference/speed
+		if speed < epsilon || guess <= low || guess >= high {
+			// falls back to bisection
+			guess = 0.5 * (low + high)
+		}
+		t = guess
+	}
+
+	return next.Segment, t
+}
+
+// Distance returns the distance along the path of the given segment (path data) and its parameter,
+// which is the inverse of Lookup
+func (a ArcLengthTable) Distance(segment int, t float64) float64 {
+	if segment < 0 || segment >= len(a.path.Data) {
+		return 0
+	}
+
+	t = mathf.Clamp(t, 0, 1)
+	// last sample at or before the parameter
+	i := sort.Search(len(a.samples), func(i int) bool {
+		sample := a.samples[i]
+		return sample.Segment > segment || (sample.Segment == segment && sample.T > t)
+	}) - 1
+	if i < 0 {
+		return 0
+	}
+	sample := a.samples[i]
+
+	return sample.Distance + a.path.Data[segment].LengthBetween(sample.T, t)
+}
+
+// PointAtDistance returns the position of the path at the given distance along it
+func (a ArcLengthTable) PointAtDistance(distance float64) vector.Vector2 {
+	segment, t := a.Lookup(distance)
+	if segment < 0 {
+		return vector.Vector2{}
+	}
+
+	return a.path.Data[segment].Point(t)
+}
+
+// TangentAtDistance returns the unit tangent of the path at the given distance along it
+func (a ArcLengthTable) TangentAtDistance(distance float64) vector.Vector2 {
+	segment, t := a.Lookup(distance)
+	if segment < 0 {
+		return vector.Vector2{}
+	}
+
+	return a.path.Data[segment].Tangent(t)
+}
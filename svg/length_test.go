@@ -0,0 +1,150 @@
+package svg
+
+import (
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// circleKappa is the distance of the control points from the endpoints of a cubic approximating a quarter of a unit circle
+const circleKappa = 0.5522847498307936
+
+// quarterCircle returns the cubic approximating the quarter of the circle with the given centre and radius,
+// counter-clockwise from the given angle
+func quarterCircle(center vector.Vector2, radius, angle float64) PathData {
+	sin, cos := math.Sincos(angle)
+	from := vector.Vector2{X: cos, Y: sin}
+	to := from.Left()
+
+	return PathData{
+		Start: center.Add(from.Mul(radius)),
+		End:   center.Add(to.Mul(radius)),
+		Control: [2]vector.Vector2{
+			center.Add(from.Add(to.Mul(circleKappa)).Mul(radius)),
+			center.Add(to.Add(from.Mul(circleKappa)).Mul(radius)),
+		},
+	}
+}
+
+// circle returns a path approximating the circle with the given centre and radius with four cubics, counter-clockwise
+func circle(center vector.Vector2, radius float64) Path {
+	var path Path
+	for i := 0; i < 4; i++ {
+		path.Data = append(path.Data, quarterCircle(center, radius, float64(i)*math.Pi/2))
+	}
+	return path
+}
+
+// approxEqual checks if two numbers differ by no more than the tolerance
+func approxEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+// approxPoint checks if two points are no further than the tolerance from each other
+func approxPoint(a, b vector.Vector2, tolerance float64) bool {
+	return a.Distance(b) <= tolerance
+}
+
+func TestPathDataLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      PathData
+		length    float64
+		tolerance float64
+	}{
+		{"horizontal line", newLine(vector.Vector2{}, vector.Vector2{X: 10}), 10, 1e-12},
+		{"diagonal line", newLine(vector.Vector2{X: 1, Y: 2}, vector.Vector2{X: 4, Y: 6}), 5, 1e-12},
+		{"zero-length line", newLine(vector.Vector2{X: 3, Y: 3}, vector.Vector2{X: 3, Y: 3}), 0, 1e-12},
+		{
+			// the controls at thirds of the line move along it at constant speed
+			"line with controls at thirds",
+			PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 9}, Control: [2]vector.Vector2{{X: 3}, {X: 6}}},
+			9, 1e-12,
+		},
+		// the cubic approximation of a quarter circle is slightly longer than the arc, by about 2e-4 of the radius
+		{"unit quarter circle", quarterCircle(vector.Vector2{}, 1, 0), math.Pi / 2, 1e-3},
+		{"quarter circle", quarterCircle(vector.Vector2{X: 5, Y: -2}, 40, math.Pi/3), 20 * math.Pi, 40e-3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if length := test.data.Length(); !approxEqual(length, test.length, test.tolerance) {
+				t.Errorf("Length() = %v, expected %v", length, test.length)
+			}
+		})
+	}
+
+	// the halves of a curve add up to the whole
+	quarter := quarterCircle(vector.Vector2{}, 1, 0)
+	if sum := quarter.LengthBetween(0, 0.3) + quarter.LengthBetween(0.3, 1); !approxEqual(sum, quarter.Length(), 1e-12) {
+		t.Errorf("LengthBetween(0, 0.3) + LengthBetween(0.3, 1) = %v, expected %v", sum, quarter.Length())
+	}
+}
+
+func TestPathLength(t *testing.T) {
+	square := Path{Data: []PathData{
+		newLine(vector.Vector2{}, vector.Vector2{X: 2}),
+		newLine(vector.Vector2{X: 2}, vector.Vector2{X: 2, Y: 2}),
+		newLine(vector.Vector2{X: 2, Y: 2}, vector.Vector2{Y: 2}),
+		newLine(vector.Vector2{Y: 2}, vector.Vector2{}),
+	}}
+	if length := square.Length(); !approxEqual(length, 8, 1e-12) {
+		t.Errorf("square Length() = %v, expected 8", length)
+	}
+	if length := circle(vector.Vector2{}, 10).Length(); !approxEqual(length, 20*math.Pi, 10e-3) {
+		t.Errorf("circle Length() = %v, expected %v", length, 20*math.Pi)
+	}
+}
+
+func TestArcLengthTable(t *testing.T) {
+	path := circle(vector.Vector2{}, 10)
+	path.Data = append(path.Data, newLine(vector.Vector2{X: 10}, vector.Vector2{X: 20}))
+	const precision = 1e-4
+	table := NewArcLengthTable(path, precision)
+
+	if !approxEqual(table.Length(), path.Length(), 1e-9) {
+		t.Fatalf("Length() = %v, expected %v", table.Length(), path.Length())
+	}
+
+	// Distance is the inverse of Lookup
+	for distance := 0.0; distance <= table.Length(); distance += table.Length() / 97 {
+		segment, parameter := table.Lookup(distance)
+		if back := table.Distance(segment, parameter); !approxEqual(back, distance, precision) {
+			t.Errorf("Distance(Lookup(%v)) = %v", distance, back)
+		}
+	}
+	for segment := range path.Data {
+		for _, parameter := range []float64{0, 0.25, 0.5, 0.9, 1} {
+			distance := table.Distance(segment, parameter)
+			s, p := table.Lookup(distance)
+			if !approxPoint(path.Data[s].Point(p), path.Data[segment].Point(parameter), 10*precision) {
+				t.Errorf("Lookup(Distance(%d, %v)) = (%d, %v)", segment, parameter, s, p)
+			}
+		}
+	}
+
+	// the boundaries between segments are at the lengths of the previous segments
+	var distance float64
+	for segment, data := range path.Data {
+		if point := table.PointAtDistance(distance); !approxPoint(point, data.Start, precision) {
+			t.Errorf("PointAtDistance(%v) = %v, expected the start of segment %d, %v", distance, point, segment, data.Start)
+		}
+		distance += data.Length()
+		if point := table.PointAtDistance(distance); !approxPoint(point, data.End, precision) {
+			t.Errorf("PointAtDistance(%v) = %v, expected the end of segment %d, %v", distance, point, segment, data.End)
+		}
+	}
+
+	// the distance is clamped to the path
+	if point := table.PointAtDistance(-5); !approxPoint(point, path.Data[0].Start, precision) {
+		t.Errorf("PointAtDistance(-5) = %v, expected the start of the path", point)
+	}
+	if point := table.PointAtDistance(1e9); !approxPoint(point, vector.Vector2{X: 20}, precision) {
+		t.Errorf("PointAtDistance(1e9) = %v, expected the end of the path", point)
+	}
+
+	// an empty path has no segments
+	if segment, _ := NewArcLengthTable(Path{}, 0).Lookup(1); segment >= 0 {
+		t.Errorf("Lookup on an empty path returned segment %d", segment)
+	}
+}
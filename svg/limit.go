@@ -0,0 +1,73 @@
+package svg
+
+import "math"
+
+// default resource limits
+const (
+	defaultMaxElements       = 100000
+	defaultMaxDepth          = 256
+	defaultMaxPathDataLength = 1 << 20
+	defaultMaxSegments       = 1000000
+	defaultMaxCoordinate     = 1e9
+)
+
+// limits represents the resolved resource limits of a parse
+// negative limits are disabled
+type limits struct {
+	Elements       int
+	Depth          int
+	PathDataLength int
+	Segments       int
+	Coordinate     float64
+}
+
+// newLimits resolves the resource limits from the parser options, falling back to the default ones
+func newLimits(options ParserOptions) limits {
+	return limits{
+		Elements:       resolveLimit(options.MaxElements, defaultMaxElements),
+		Depth:          resolveLimit(options.MaxDepth, defaultMaxDepth),
+		PathDataLength: resolveLimit(options.MaxPathDataLength, defaultMaxPathDataLength),
+		Segments:       resolveLimit(options.MaxSegments, defaultMaxSegments),
+		Coordinate:     resolveFloatLimit(options.MaxCoordinate, defaultMaxCoordinate),
+	}
+}
+
+// resolveLimit returns the given limit, or the fallback one if it is zero
+func resolveLimit(limit, fallback int) int {
+	if limit == 0 {
+		return fallback
+	}
+	return limit
+}
+
+// resolveFloatLimit returns the given limit, or the fallback one if it is zero
+func resolveFloatLimit(limit, fallback float64) float64 {
+	if limit == 0 {
+		return fallback
+	}
+	return limit
+}
+
+// exceeds checks if the given value exceeds the limit
+func exceeds(value, limit int) bool {
+	return limit >= 0 && value > limit
+}
+
+// validateCoordinates checks if every point of the path data is finite and within the maximum magnitude
+func validateCoordinates(data []PathData, limit float64) error {
+	for _, d := range data {
+		for _, point := range [...]float64{
+			d.Start.X, d.Start.Y, d.End.X, d.End.Y,
+			d.Control[0].X, d.Control[0].Y, d.Control[1].X, d.Control[1].Y,
+		} {
+			if math.IsNaN(point) || math.IsInf(point, 0) {
+				return newNonFiniteCoordinateError(point)
+			}
+			if limit >= 0 && math.Abs(point) > limit {
+				return newLimitExceededError(coordinateLimit, limit)
+			}
+		}
+	}
+
+	return nil
+}
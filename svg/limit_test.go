@@ -0,0 +1,199 @@
+package svg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// corpus is a set of documents exercising most of the parser, used as seeds of the randomized test
+var corpus = []string{
+	`<svg><path id="a" d="M0 0 L10 0 L10 10 Z"/></svg>`,
+	`<svg><g inkscape:groupmode="layer" inkscape:label="L"><path d="m1 1 h5 v5 h-5 z" fill-rule="evenodd"/></g></svg>`,
+	`<svg><path d="M0 0 C 1 2 3 4 5 6 c 1 1 2 2 3 3 S 1 1 2 2"/></svg>`,
+	`<svg><clipPath id="c" clipPathUnits="objectBoundingBox"><path d="M0 0 H1 V1 Z" transform="scale(0.5)"/></clipPath>` +
+		`<g clip-path="url(#c)"><path d="M0 0 L10 0 L10 10 L0 10 Z"/></g></svg>`,
+	`<svg><path style="stroke-width:2;stroke-dasharray:1 2" d="M0 0 L1e8 1e8"/></svg>`,
+}
+
+// documentTokens are the fragments the random documents are made of
+var documentTokens = []string{
+	"<svg>", "</svg>", "<g>", "</g>", "<path d=\"", "\"/>", "<clipPath id=\"c\">", "</clipPath>",
+	" clip-path=\"url(#c)\"", " transform=\"rotate(45 1 1)\"", "M", "m", "L", "l", "H", "v", "C", "c", "Z", "z",
+	"0", "1", "-1", "1e308", "-1e308", "1e400", "NaN", "Inf", ".5", "1e-320", ",", " ", "\"", "<", ">", "&",
+}
+
+// checkParse parses the data, failing if it panics, returns paths with non-finite coordinates or exceeds the limits
+func checkParse(t *testing.T, data []byte, options ParserOptions) error {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("ParsePath(%q) panicked: %v", data, r)
+		}
+	}()
+
+	paths, err := ParsePath(data, options)
+	limits := newLimits(options)
+	segments := 0
+	for _, path := range paths {
+		segments += len(path.Data)
+		if err := validateCoordinates(path.Data, limits.Coordinate); err != nil {
+			t.Fatalf("ParsePath(%q) returned invalid coordinates: %v", data, err)
+		}
+	}
+	if exceeds(segments, limits.Segments) {
+		t.Fatalf("ParsePath(%q) returned %d segments, above the limit of %d", data, segments, limits.Segments)
+	}
+
+	return err
+}
+
+func TestParsePathRandomized(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	options := []ParserOptions{
+		{},
+		{FlatteningTolerance: 0.1, MaxSegments: 10000, ExactClipping: true},
+		{MaxCoordinate: -1, MaxSegments: 100, CleanupTolerance: 0.5, Simplification: DouglasPeucker, SimplificationTolerance: 1},
+	}
+
+	for i := 0; i < 3000; i++ {
+		var data []byte
+		if i%2 == 0 {
+			// a document of the corpus with a few bytes replaced, inserted or removed
+			data = []byte(corpus[random.Intn(len(corpus))])
+			for n := random.Intn(4) + 1; n > 0 && len(data) > 0; n-- {
+				k := random.Intn(len(data))
+				switch random.Intn(3) {
+				case 0:
+					data[k] = byte(random.Intn(256))
+				case 1:
+					data = append(data[:k], append([]byte{byte(random.Intn(128))}, data[k:]...)...)
+				default:
+					data = append(data[:k], data[k+1:]...)
+				}
+			}
+		} else {
+			// a document made of random tokens
+			var b strings.Builder
+			b.WriteString("<svg>")
+			for n := random.Intn(60); n > 0; n-- {
+				b.WriteString(documentTokens[random.Intn(len(documentTokens))])
+			}
+			data = []byte(b.String())
+		}
+
+		checkParse(t, data, options[i%len(options)])
+	}
+}
+
+func TestParsePathTruncated(t *testing.T) {
+	for _, document := range corpus {
+		for i := 0; i <= len(document); i++ {
+			checkParse(t, []byte(document[:i]), ParserOptions{})
+		}
+	}
+}
+
+func TestParsePathLimits(t *testing.T) {
+	deep := strings.Repeat("<g>", 100000) + `<path d="M0 0 L1 1"/>` + strings.Repeat("</g>", 100000)
+
+	var curves strings.Builder
+	curves.WriteString("M0 0")
+	for i := 0; i < 400; i++ {
+		curves.WriteString(" C 1e8 1e8 -1e8 1e8 0 0")
+	}
+	flattened := `<svg><path d="` + curves.String() + `"/></svg>`
+	clipped := `<svg><clipPath id="c"><path d="` + curves.String() + ` Z"/></clipPath>` +
+		`<path clip-path="url(#c)" d="` + curves.String() + `"/></svg>`
+
+	tests := []struct {
+		name    string
+		data    string
+		options ParserOptions
+		limit   string
+	}{
+		{"deep nesting", "<svg>" + deep + "</svg>", ParserOptions{}, depthLimit},
+		{"many elements", "<svg>" + strings.Repeat("<g/>", 200000) + "</svg>", ParserOptions{}, elementCountLimit},
+		{"long path data", `<svg><path d="M0 0` + strings.Repeat(" L1 1", 300000) + `"/></svg>`, ParserOptions{}, pathDataLengthLimit},
+		{"many segments", `<svg><path d="M0 0` + strings.Repeat(" L1 1", 200) + `"/></svg>`, ParserOptions{MaxSegments: 100}, segmentCountLimit},
+		{"huge coordinate", `<svg><path d="M0 0 L1e300 0"/></svg>`, ParserOptions{}, coordinateLimit},
+		{"flattening", flattened, ParserOptions{FlatteningTolerance: 0.1}, segmentCountLimit},
+		{"exact clipping", clipped, ParserOptions{ExactClipping: true}, segmentCountLimit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var before runtime.MemStats
+			runtime.ReadMemStats(&before)
+
+			err := checkParse(t, []byte(test.data), test.options)
+			var limitErr LimitExceededError
+			if !errors.As(err, &limitErr) || limitErr.Limit != test.limit {
+				t.Fatalf("expected the %s limit to be exceeded, got %v", test.limit, err)
+			}
+
+			// the work stops as soon as the limit is exceeded, long before running away
+			var after runtime.MemStats
+			runtime.ReadMemStats(&after)
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<30 {
+				t.Fatalf("allocated %d bytes before failing", allocated)
+			}
+		})
+	}
+}
+
+func TestParsePathNonFinite(t *testing.T) {
+	tests := []string{
+		`<svg><path d="M0 0 l 1e308 1 l 1e308 1"/></svg>`,
+		`<svg><path d="M1e308 0 h 1e308"/></svg>`,
+		`<svg><path d="M0 0 c 1e308 0 1e308 0 1e308 0 c 1e308 0 1e308 0 1e308 0"/></svg>`,
+		// the literal values, in either axis; "NaN" and "Infinity" contain path commands, so they are not numbers
+		`<svg><path d="M0 0 L Inf 1"/></svg>`,
+		`<svg><path d="M0 0 L 1 -Inf"/></svg>`,
+		`<svg><path d="M0 0 L 1e400 1"/></svg>`,
+		`<svg><path d="M0 0 C 1 1 2 2 3 -1e400"/></svg>`,
+	}
+	for _, data := range tests {
+		// the overflow is reported as such, even without a maximum magnitude
+		err := checkParse(t, []byte(data), ParserOptions{MaxCoordinate: -1})
+		var nonFinite NonFiniteCoordinateError
+		if !errors.As(err, &nonFinite) || !(math.IsInf(nonFinite.Value, 0) || math.IsNaN(nonFinite.Value)) {
+			t.Errorf("%s: expected a non-finite coordinate error, got %v", data, err)
+		}
+	}
+}
+
+func TestParsePathReaderLimits(t *testing.T) {
+	document := []byte(`<svg><path d="M0 0 L1 1"/>` + strings.Repeat(" ", 1<<20) + `</svg>`)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(bytes.Repeat(document, 16))
+	gz.Close()
+
+	tests := []struct {
+		name  string
+		data  []byte
+		limit string
+	}{
+		{"gzip bomb", compressed.Bytes(), decompressedSizeLimit},
+		{"uncompressed", document, readSizeLimit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePathReader(bytes.NewReader(test.data), ParserOptions{MaxDecompressedSize: 1 << 16})
+			var limitErr LimitExceededError
+			if !errors.As(err, &limitErr) || limitErr.Limit != test.limit {
+				t.Fatalf("expected the %s limit to be exceeded, got %v", test.limit, err)
+			}
+		})
+	}
+
+	// the same data is parsed within the default limit
+	if _, err := ParsePathReader(bytes.NewReader(compressed.Bytes()), ParserOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
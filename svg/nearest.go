@@ -0,0 +1,165 @@
+package svg
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// nearest point settings
+const (
+	// maximum number of times a curve is subdivided while looking for the nearest point
+	maxNearestDepth = 24
+	// flatness, relative to the size of the curve, below which a subdivided part is projected onto its chord
+	nearestFlatness = 1e-4
+	// maximum number of Newton iterations refining the nearest point
+	maxNewtonIterations = 8
+)
+
+// Projection represents the point of a path nearest to another point
+type Projection struct {
+	// Point contains the nearest point of the path
+	Point vector.Vector2
+	// Distance contains the distance between the nearest point and the other point
+	Distance float64
+	// Segment contains the index of the segment (path data) of the nearest point
+	Segment int
+	// T contains the parameter of the nearest point in its segment
+	T float64
+	// Length contains the distance along the path up to the nearest point
+	Length float64
+}
+
+// Nearest returns the parameter of the point of the curve nearest to the given point, along with the nearest point
+// and its distance to the given point
+func (p PathData) Nearest(point vector.Vector2) (float64, vector.Vector2, float64) {
+	// the endpoints are the initial candidates
+	search := nearestSearch{curve: p, point: point, t: 0, distance: p.Start.Distance(point)}
+	if d := p.End.Distance(point); d < search.distance {
+		search.t, search.distance = 1, d
+	}
+
+	box := p.controlBox()
+	search.flatness = nearestFlatness*box.Size().Magnitude() + epsilon
+	search.subdivide(p, 0, 1, 0)
+
+	return search.t, p.Point(search.t), search.distance
+}
+
+// nearestSearch holds the state of the search for the nearest point of a curve
+type nearestSearch struct {
+	curve    PathData
+	point    vector.Vector2
+	flatness float64
+	// best candidate so far
+	t, distance float64
+}
+
+// subdivide looks for the nearest point in the part of the curve between t0 and t1, subdividing it
+// until it is flat enough to be projected onto its chord; parts that cannot be nearer are pruned
+func (s *nearestSearch) subdivide(part PathData, t0, t1 float64, depth int) {
+	// the control points enclose the curve, so their box bounds the distance from below
+	if part.controlBox().distance(s.point) > s.distance {
+		return
+	}
+
+	if depth >= maxNearestDepth || part.flatness() <= s.flatness {
+		// initial guess from the projection onto the chord, refined on the whole curve
+		chord := part.End.Sub(part.Start)
+		var u float64
+		if length := chord.MagnitudeSqr(); length > epsilon*epsilon {
+			u = mathf.Clamp(s.point.Sub(part.Start).Dot(chord)/length, 0, 1)
+		}
+		s.refine(t0+u*(t1-t0), t0, t1)
+
+		return
+	}
+
+	left, right := part.Split(0.5)
+	middle := 0.5 * (t0 + t1)
+	// visits the nearest half first, to prune more of the other one
+	if left.controlBox().distance(s.point) <= right.controlBox().distance(s.point) {
+		s.subdivide(left, t0, middle, depth+1)
+		s.subdivide(right, middle, t1, depth+1)
+	} else {
+		s.subdivide(right, middle, t1, depth+1)
+		s.subdivide(left, t0, middle, depth+1)
+	}
+}
+
+// refine improves the given parameter with Newton's method, minimising the squared distance within [low, high],
+// and keeps it if it is the best candidate so far
+func (s *nearestSearch) refine(t, low, high float64) {
+	best, bestDistance := t, s.curve.Point(t).Distance(s.point)
+	for i := 0; i < maxNewtonIterations; i++ {
+		difference := s.curve.Point(t).Sub(s.point)
+		first := s.curve.Derivative(t)
+		second := s.curve.SecondDerivative(t)
+
+		// derivative of the squared distance (halved) and its own derivative
+		f := difference.Dot(first)
+		df := first.Dot(first) + difference.Dot(second)
+		// near cusps the derivatives vanish, so the initial guess is kept
+		if math.Abs(df) < epsilon {
+			break
+		}
+
+		next := mathf.Clamp(t-f/df, low, high)
+		if d := s.curve.Point(next).Distance(s.point); d < bestDistance {
+			best, bestDistance = next, d
+		}
+		if math.Abs(next-t) < epsilon {
+			break
+		}
+		t = next
+	}
+
+	if bestDistance < s.distance {
+		s.t, s.distance = best, bestDistance
+	}
+}
+
+// controlBox returns the bounding box of the endpoints and control points, which encloses the curve
+func (p PathData) controlBox() Box {
+	return emptyBox().Extend(p.Start).Extend(p.End).Extend(p.Control[0]).Extend(p.Control[1])
+}
+
+// distance returns the distance between the box and the given point, which is zero inside the box
+func (b Box) distance(point vector.Vector2) float64 {
+	dx := math.Max(0, math.Max(b.Min.X-point.X, point.X-b.Max.X))
+	dy := math.Max(0, math.Max(b.Min.Y-point.Y, point.Y-b.Max.Y))
+
+	return math.Hypot(dx, dy)
+}
+
+// Nearest returns the projection of the given point onto the path, i.e. the nearest point of the path
+// an empty path returns a negative segment index and an infinite distance
+func (p Path) Nearest(point vector.Vector2) Projection {
+	projection := Projection{Segment: -1, Distance: math.Inf(1)}
+	for i, data := range p.Data {
+		// skips the segments that cannot be nearer
+		if data.controlBox().distance(point) > projection.Distance {
+			continue
+		}
+
+		t, nearest, distance := data.Nearest(point)
+		if distance < projection.Distance {
+			projection = Projection{
+				Point:    nearest,
+				Distance: distance,
+				Segment:  i,
+				T:        t,
+			}
+		}
+	}
+
+	if projection.Segment >= 0 {
+		for _, data := range p.Data[:projection.Segment] {
+			projection.Length += data.Length()
+		}
+		projection.Length += p.Data[projection.Segment].LengthBetween(0, projection.T)
+	}
+
+	return projection
+}
@@ -0,0 +1,103 @@
+package svg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// bruteForceNearest returns the distance between the point and the nearest of many samples of the curve
+func bruteForceNearest(data PathData, point vector.Vector2) float64 {
+	const samples = 20000
+	nearest := math.Inf(1)
+	for i := 0; i <= samples; i++ {
+		nearest = math.Min(nearest, data.Point(float64(i)/samples).Distance(point))
+	}
+	return nearest
+}
+
+func TestPathDataNearest(t *testing.T) {
+	tests := []struct {
+		name string
+		data PathData
+	}{
+		// the derivative vanishes at t = 0.5, where the curve has a cusp at (0.5, 0.75)
+		{"cusp", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 1, Y: 1}, {Y: 1}}}},
+		{"loop", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 1}, Control: [2]vector.Vector2{{X: 2, Y: 1}, {X: -1, Y: 1}}}},
+		{"quarter circle", quarterCircle(vector.Vector2{}, 1, 0)},
+		{"line", newLine(vector.Vector2{X: -1, Y: -1}, vector.Vector2{X: 2, Y: 1})},
+		{"zero-length line", newLine(vector.Vector2{X: 0.5, Y: 0.5}, vector.Vector2{X: 0.5, Y: 0.5})},
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				point := vector.Vector2{X: 4*random.Float64() - 1.5, Y: 4*random.Float64() - 1.5}
+				parameter, nearest, distance := test.data.Nearest(point)
+
+				if parameter < 0 || parameter > 1 {
+					t.Fatalf("Nearest(%v) returned the parameter %v, out of range", point, parameter)
+				}
+				if !approxPoint(nearest, test.data.Point(parameter), 1e-12) || !approxEqual(distance, nearest.Distance(point), 1e-12) {
+					t.Fatalf("Nearest(%v) = (%v, %v, %v), which is inconsistent", point, parameter, nearest, distance)
+				}
+				// none of the samples is nearer, and the nearest sample is close to the nearest point
+				if expected := bruteForceNearest(test.data, point); distance > expected+1e-9 || distance < expected-1e-3 {
+					t.Fatalf("Nearest(%v) = %v, expected %v", point, distance, expected)
+				}
+			}
+		})
+	}
+
+	// the nearest point to the tip of the cusp, from above, is the tip itself
+	cusp := tests[0].data
+	if parameter, nearest, distance := cusp.Nearest(vector.Vector2{X: 0.5, Y: 1}); !approxEqual(parameter, 0.5, 1e-6) ||
+		!approxPoint(nearest, vector.Vector2{X: 0.5, Y: 0.75}, 1e-9) || !approxEqual(distance, 0.25, 1e-9) {
+		t.Errorf("Nearest((0.5, 1)) = (%v, %v, %v), expected the cusp at (0.5, 0.75)", parameter, nearest, distance)
+	}
+}
+
+func TestPathNearest(t *testing.T) {
+	// the controls halfway between the endpoints do not move along the line at constant speed,
+	// so the parameter differs from the fraction of the length
+	path := Path{Data: []PathData{
+		newLine(vector.Vector2{}, vector.Vector2{X: 10}),
+		newLine(vector.Vector2{X: 10}, vector.Vector2{X: 10, Y: 10}),
+	}}
+
+	tests := []struct {
+		point   vector.Vector2
+		nearest vector.Vector2
+		segment int
+		length  float64
+	}{
+		{vector.Vector2{X: 3, Y: -5}, vector.Vector2{X: 3}, 0, 3},
+		{vector.Vector2{X: 8, Y: 0}, vector.Vector2{X: 8}, 0, 8},
+		{vector.Vector2{X: 14, Y: 6}, vector.Vector2{X: 10, Y: 6}, 1, 16},
+		{vector.Vector2{X: 12, Y: 20}, vector.Vector2{X: 10, Y: 10}, 1, 20},
+		{vector.Vector2{X: -4, Y: 3}, vector.Vector2{}, 0, 0},
+	}
+	for _, test := range tests {
+		projection := path.Nearest(test.point)
+		if projection.Segment != test.segment || !approxPoint(projection.Point, test.nearest, 1e-9) ||
+			!approxEqual(projection.Distance, test.point.Distance(test.nearest), 1e-9) {
+			t.Errorf("Nearest(%v) = %+v, expected %v on segment %d", test.point, projection, test.nearest, test.segment)
+			continue
+		}
+		// the parameter and the length agree with the arc length of the segment
+		if !approxPoint(path.Data[projection.Segment].Point(projection.T), projection.Point, 1e-9) {
+			t.Errorf("Nearest(%v): the parameter %v is not at the nearest point", test.point, projection.T)
+		}
+		if !approxEqual(projection.Length, test.length, 1e-6) {
+			t.Errorf("Nearest(%v): Length = %v, expected %v", test.point, projection.Length, test.length)
+		}
+	}
+
+	// an empty path has no nearest point
+	if projection := (Path{}).Nearest(vector.Vector2{X: 1}); projection.Segment != -1 || !math.IsInf(projection.Distance, 1) {
+		t.Errorf("Nearest on an empty path = %+v, expected a negative segment and an infinite distance", projection)
+	}
+}
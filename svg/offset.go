@@ -0,0 +1,261 @@
+package svg
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// LineJoin represents the shape used at the corners where two segments meet
+type LineJoin int
+
+const (
+	// MiterJoin extends the edges until they meet, falling back to BevelJoin beyond the miter limit
+	MiterJoin LineJoin = iota
+	// RoundJoin joins the edges with a circular arc
+	RoundJoin
+	// BevelJoin joins the edges with a straight line
+	BevelJoin
+)
+
+// offset settings
+const (
+	// default miter limit, as in SVG
+	defaultMiterLimit = 4
+	// maximum number of times a curve is subdivided while offsetting
+	maxOffsetDepth = 10
+	// number of intervals between the points where an offset curve is compared with the exact offset
+	offsetErrorSamples = 16
+)
+
+// OffsetOptions are used to configure the offset of a path
+type OffsetOptions struct {
+	// shape used at the corners on the outer side of the path
+	Join LineJoin
+	// maximum ratio between the miter length and the offset distance; zero or less uses the default (4)
+	MiterLimit float64
+	// maximum distance between the offset curves and the exact offset; zero or less uses the default (0.1)
+	Tolerance float64
+}
+
+// withDefaults returns the options, replacing the unset values by their defaults
+func (o OffsetOptions) withDefaults() OffsetOptions {
+	if o.MiterLimit <= 0 {
+		o.MiterLimit = defaultMiterLimit
+	}
+	if o.Tolerance <= 0 {
+		o.Tolerance = defaultFlatteningTolerance
+	}
+	return o
+}
+
+// Offset returns a path parallel to this one, at the given signed distance
+// positive distances offset towards the normal (see PathData.Normal) and negative ones to the opposite side;
+// the corners on the outer side are joined according to the options, while the ones on the inner side are
+// connected by straight lines; the offsets overlap at the inner corners, and wherever the distance exceeds
+// the radius of curvature, leaving small self-intersecting loops, which are not trimmed (see Path.SelfIntersections);
+// segments without length are ignored
+func (p Path) Offset(distance float64, options OffsetOptions) Path {
+	options = options.withDefaults()
+
+	offset := p
+	offset.Data = nil
+	for _, subpath := range p.Subpaths() {
+		offset.Data = append(offset.Data, subpath.offsetSubpath(distance, options, false)...)
+	}
+
+	return offset
+}
+
+// offsetSubpath returns the offset of a continuous subpath, including the joins between its segments
+// when pivoting, the inner corners are connected through their vertex, which keeps the winding of outlines consistent
+func (p Path) offsetSubpath(distance float64, options OffsetOptions, pivot bool) []PathData {
+	var data []PathData
+	closed := p.IsClosed()
+	// the segments without length have no direction, so the corners are joined between the segments around them
+	p = p.withoutDegenerateSegments()
+	for i, segment := range p.Data {
+		data = append(data, segment.Offset(distance, options.Tolerance)...)
+
+		// joins this segment with the next one, including the closing corner of closed subpaths
+		if i+1 < len(p.Data) {
+			data = append(data, offsetJoin(segment, p.Data[i+1], distance, options, pivot)...)
+		} else if closed {
+			data = append(data, offsetJoin(segment, p.Data[0], distance, options, pivot)...)
+		}
+	}
+
+	return data
+}
+
+// withoutDegenerateSegments returns a copy of the path without the segments shorter than epsilon, like the line
+// closing a subpath that already ends at its start (e.g. "M0 0 L10 0 L10 10 L0 0 Z")
+func (p Path) withoutDegenerateSegments() Path {
+	data := make([]PathData, 0, len(p.Data))
+	for _, segment := range p.Data {
+		if segment.Length() >= epsilon {
+			data = append(data, segment)
+		}
+	}
+	p.Data = data
+
+	return p
+}
+
+// offsetJoin returns the curves joining the offsets of two consecutive segments
+func offsetJoin(in, out PathData, distance float64, options OffsetOptions, pivot bool) []PathData {
+	tangentIn, tangentOut := in.Tangent(1), out.Tangent(0)
+	from := in.End.Add(tangentIn.Left().Mul(distance))
+	to := out.Start.Add(tangentOut.Left().Mul(distance))
+	if from.Distance(to) < epsilon {
+		return nil
+	}
+
+	// on the inner side of the corner, the offsets overlap and are simply connected
+	if tangentIn.Cross(tangentOut)*distance > 0 {
+		if pivot {
+			return []PathData{newLine(from, in.End), newLine(in.End, to)}
+		}
+		return []PathData{newLine(from, to)}
+	}
+
+	return joinCurves(in.End, from, to, tangentIn, tangentOut, options.Join, options.MiterLimit)
+}
+
+// joinCurves returns the curves joining the points from and to, around the corner at the given vertex,
+// where the incoming and outgoing edges have the given tangents; from and to are equally distant from the vertex
+func joinCurves(vertex, from, to, tangentIn, tangentOut vector.Vector2, join LineJoin, miterLimit float64) []PathData {
+	switch join {
+	case RoundJoin:
+		return arcCurves(vertex, from, to, tangentIn.Cross(tangentOut) < 0)
+	case MiterJoin:
+		// the miter length, relative to the distance, is 1/sin(θ/2), where θ is the angle between the edges
+		cosTheta := -tangentIn.Dot(tangentOut)
+		sinHalf := math.Sqrt(math.Max(0, 0.5*(1-cosTheta)))
+		if sinHalf > epsilon && 1/sinHalf <= miterLimit {
+			if u, _, ok := lineIntersection(from, tangentIn, to, tangentOut); ok {
+				tip := from.Add(tangentIn.Mul(u))
+				return []PathData{newLine(from, tip), newLine(tip, to)}
+			}
+		}
+	}
+
+	return []PathData{newLine(from, to)}
+}
+
+// lineIntersection returns the parameters of the intersection of the infinite lines a + u*da and b + v*db
+func lineIntersection(a, da, b, db vector.Vector2) (float64, float64, bool) {
+	denominator := da.Cross(db)
+	if math.Abs(denominator) < epsilon {
+		return 0, 0, false
+	}
+
+	offset := b.Sub(a)
+	return offset.Cross(db) / denominator, offset.Cross(da) / denominator, true
+}
+
+// arcCurves approximates the circular arc around the center, from one point to another, by cubic curves
+// the arc runs clockwise (in a y-up coordinate system) when requested, and counter-clockwise otherwise
+func arcCurves(center, from, to vector.Vector2, clockwise bool) []PathData {
+	radius := from.Distance(center)
+	start := math.Atan2(from.Y-center.Y, from.X-center.X)
+	sweep := math.Atan2(to.Y-center.Y, to.X-center.X) - start
+	// normalises the sweep to the requested direction
+	if clockwise {
+		for sweep > 0 {
+			sweep -= 2 * math.Pi
+		}
+	} else {
+		for sweep < 0 {
+			sweep += 2 * math.Pi
+		}
+	}
+
+	// each curve spans at most a quarter of a circle, keeping the approximation error negligible
+	n := int(math.Ceil(math.Abs(sweep) / (0.5 * math.Pi)))
+	if n == 0 {
+		return nil
+	}
+	step := sweep / float64(n)
+	// distance of the control points from the endpoints, along the tangents
+	handle := 4.0 / 3 * math.Tan(step/4) * radius
+
+	curves := make([]PathData, n)
+	previous := from
+	for i := 0; i < n; i++ {
+		angle := start + step*float64(i+1)
+		next := vector.Vector2{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+		if i == n-1 {
+			next = to
+		}
+
+		// the tangents of a counter-clockwise arc point to the left of the radius
+		startTangent := previous.Sub(center).Left().Normalized()
+		endTangent := next.Sub(center).Left().Normalized()
+		curves[i] = PathData{
+			Start:   previous,
+			End:     next,
+			Control: [2]vector.Vector2{previous.Add(startTangent.Mul(handle)), next.Sub(endTangent.Mul(handle))},
+		}
+		previous = next
+	}
+
+	return curves
+}
+
+// Offset approximates the curve at the given signed distance by cubic curves, within the given tolerance
+// positive distances offset towards the normal (see Normal) and negative ones to the opposite side;
+// straight lines are offset exactly
+func (p PathData) Offset(distance, tolerance float64) []PathData {
+	if tolerance <= 0 {
+		tolerance = defaultFlatteningTolerance
+	}
+	if p.IsLine() {
+		normal := p.Normal(0).Mul(distance)
+		return []PathData{newLine(p.Start.Add(normal), p.End.Add(normal))}
+	}
+
+	return p.offset(distance, tolerance, 0)
+}
+
+// offset approximates the offset curve, subdividing the curve until the approximation is within the tolerance
+func (p PathData) offset(distance, tolerance float64, depth int) []PathData {
+	approximation := p.offsetApproximation(distance)
+	if depth >= maxOffsetDepth || p.offsetError(approximation, distance) <= tolerance {
+		return []PathData{approximation}
+	}
+
+	left, right := p.Split(0.5)
+	return append(left.offset(distance, tolerance, depth+1), right.offset(distance, tolerance, depth+1)...)
+}
+
+// offsetApproximation approximates the offset curve by a single cubic curve, moving the endpoints along their normals
+// and scaling the handles by the change of speed of the offset curve, (1 - distance*curvature)
+func (p PathData) offsetApproximation(distance float64) PathData {
+	start := p.Start.Add(p.Normal(0).Mul(distance))
+	end := p.End.Add(p.Normal(1).Mul(distance))
+	startScale := 1 - distance*p.Curvature(0)
+	endScale := 1 - distance*p.Curvature(1)
+
+	return PathData{
+		Start: start,
+		End:   end,
+		Control: [2]vector.Vector2{
+			start.Add(p.Control[0].Sub(p.Start).Mul(startScale)),
+			end.Add(p.Control[1].Sub(p.End).Mul(endScale)),
+		},
+	}
+}
+
+// offsetError estimates the maximum distance between the approximation and the exact offset curve,
+// comparing them at evenly spaced parameters
+func (p PathData) offsetError(approximation PathData, distance float64) float64 {
+	var maxError float64
+	for i := 1; i < offsetErrorSamples; i++ {
+		t := float64(i) / offsetErrorSamples
+		exact := p.Point(t).Add(p.Normal(t).Mul(distance))
+		maxError = math.Max(maxError, approximation.Point(t).Distance(exact))
+	}
+
+	return maxError
+}
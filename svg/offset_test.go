@@ -0,0 +1,106 @@
+package svg
+
+import (
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// closedSquares are the spellings of the same square, with and without the redundant line back to the start
+var closedSquares = map[string]string{
+	"Z":            "M0 0 L10 0 L10 10 L0 10 Z",
+	"explicit + Z": "M0 0 L10 0 L10 10 L0 10 L0 0 Z",
+}
+
+// parseSinglePath returns the only path of a document with the given path data
+func parseSinglePath(t *testing.T, data string) Path {
+	t.Helper()
+	paths, err := ParsePath([]byte(`<svg><path d="`+data+`"/></svg>`), ParserOptions{})
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("ParsePath(%q) returned %d paths and the error %v", data, len(paths), err)
+	}
+	return paths[0]
+}
+
+// maxOffsetDeviation returns how much the distance between sampled points of the offset and the curve
+// differs from the offset distance; the curve must not come back near itself, like loops do
+func maxOffsetDeviation(curve PathData, offset []PathData, distance float64) float64 {
+	var deviation float64
+	for _, d := range offset {
+		for i := 0; i <= 32; i++ {
+			_, _, nearest := curve.Nearest(d.Point(float64(i) / 32))
+			deviation = math.Max(deviation, math.Abs(nearest-math.Abs(distance)))
+		}
+	}
+	return deviation
+}
+
+func TestPathOffset(t *testing.T) {
+	// the square is counter-clockwise, so its normals point inwards and negative distances grow it
+	tests := []struct {
+		name      string
+		options   OffsetOptions
+		area      float64
+		tolerance float64
+	}{
+		{"miter", OffsetOptions{Join: MiterJoin}, 144, 1e-9},
+		{"bevel", OffsetOptions{Join: BevelJoin}, 142, 1e-9},
+		{"round", OffsetOptions{Join: RoundJoin}, 140 + math.Pi, 1e-3},
+		// the miter of a right angle is √2 times the distance, beyond this limit
+		{"miter beyond the limit", OffsetOptions{Join: MiterJoin, MiterLimit: 1.2}, 142, 1e-9},
+	}
+	for spelling, data := range closedSquares {
+		square := parseSinglePath(t, data)
+		for _, test := range tests {
+			offset := square.Offset(-1, test.options)
+			if area := offset.Area(); !approxEqual(area, test.area, test.tolerance) {
+				t.Errorf("%s, %s: Area() = %v, expected %v", spelling, test.name, area, test.area)
+			}
+			if !offset.IsClosed() {
+				t.Errorf("%s, %s: the offset is not closed", spelling, test.name)
+			}
+		}
+
+		expected := Box{Min: vector.Vector2{X: -1, Y: -1}, Max: vector.Vector2{X: 11, Y: 11}}
+		if bounds := square.Offset(-1, OffsetOptions{}).Bounds(); !approxPoint(bounds.Min, expected.Min, 1e-9) || !approxPoint(bounds.Max, expected.Max, 1e-9) {
+			t.Errorf("%s: the bounding box of the mitered offset is %v, expected %v", spelling, bounds, expected)
+		}
+		// the inner corners are connected by straight lines, which cross the offset edges
+		if intersections := square.Offset(1, OffsetOptions{}).SelfIntersections(); len(intersections) != 4 {
+			t.Errorf("%s: the inner offset crosses itself %d times, expected once per corner", spelling, len(intersections))
+		}
+	}
+}
+
+func TestPathDataOffset(t *testing.T) {
+	const tolerance = 0.005
+	tests := []struct {
+		name     string
+		curve    PathData
+		distance float64
+	}{
+		{"line", newLine(vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 4, Y: 5}), 2},
+		{"quarter circle outside", quarterCircle(vector.Vector2{}, 10, 0), -2},
+		{"quarter circle inside", quarterCircle(vector.Vector2{}, 10, 0), 2},
+		// the curvature changes quickly along these curves, far from the samples in the middle of the curve
+		{"arch", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 10}, Control: [2]vector.Vector2{{Y: 10}, {X: 10, Y: 10}}}, 2},
+		// the radius of curvature at the turn is only slightly larger than the distance
+		{"tight turn", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 10}, Control: [2]vector.Vector2{{X: 14.4, Y: -10}, {X: 18.2, Y: -2.6}}}, 1},
+		{"s curve", PathData{Start: vector.Vector2{}, End: vector.Vector2{X: 10}, Control: [2]vector.Vector2{{X: 10, Y: 10}, {Y: -10}}}, 0.5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offset := test.curve.Offset(test.distance, tolerance)
+			if deviation := maxOffsetDeviation(test.curve, offset, test.distance); deviation > tolerance {
+				t.Errorf("the offset is up to %v away from the exact offset, beyond the tolerance %v", deviation, tolerance)
+			}
+			// the offset starts and ends at the normals of the endpoints
+			start := test.curve.Start.Add(test.curve.Normal(0).Mul(test.distance))
+			end := test.curve.End.Add(test.curve.Normal(1).Mul(test.distance))
+			if !approxPoint(offset[0].Start, start, 1e-9) || !approxPoint(offset[len(offset)-1].End, end, 1e-9) {
+				t.Errorf("the offset goes from %v to %v, expected %v to %v", offset[0].Start, offset[len(offset)-1].End, start, end)
+			}
+		})
+	}
+}
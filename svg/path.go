@@ -660,6 +660,10 @@ func parsePoint(x, y, command string) (vector.Vector2, error) {
 // parseX parses the given x-axes and returns its value
 func parseX(x, command string) (float64, error) {
 	axis, err := strconv.ParseFloat(x, 0)
+	// NaN, infinite values and the ones too large to be represented are not finite coordinates
+	if math.IsNaN(axis) || math.IsInf(axis, 0) {
+		return 0, newNonFiniteCoordinateError(axis)
+	}
 	if err != nil {
 		return 0, newInvalidXError(command, x)
 	}
@@ -670,6 +674,10 @@ func parseX(x, command string) (float64, error) {
 // parseY parses the given y-axes and returns its value
 func parseY(y, command string) (float64, error) {
 	axis, err := strconv.ParseFloat(y, 0)
+	// NaN, infinite values and the ones too large to be represented are not finite coordinates
+	if math.IsNaN(axis) || math.IsInf(axis, 0) {
+		return 0, newNonFiniteCoordinateError(axis)
+	}
 	if err != nil {
 		return 0, newInvalidYError(command, y)
 	}
@@ -0,0 +1,90 @@
+package svg
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+)
+
+// solveQuadratic returns the real roots of a*x^2 + b*x + c = 0
+// degenerates into a linear equation when a is (nearly) zero
+func solveQuadratic(a, b, c float64) []float64 {
+	if math.Abs(a) < epsilon {
+		if math.Abs(b) < epsilon {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	}
+	if discriminant == 0 {
+		return []float64{-b / (2 * a)}
+	}
+
+	// numerically stable form, avoiding the cancellation of -b + sqrt(discriminant)
+	q := -0.5 * (b + math.Copysign(math.Sqrt(discriminant), b))
+	return []float64{q / a, c / q}
+}
+
+// unitRoots returns the given roots that are inside the range (0, 1)
+func unitRoots(roots []float64) []float64 {
+	var result []float64
+	for _, root := range roots {
+		if root > 0 && root < 1 {
+			result = append(result, root)
+		}
+	}
+
+	return result
+}
+
+// solveCubic returns the real roots of a*x^3 + b*x^2 + c*x + d = 0
+// degenerates into a quadratic equation when a is (nearly) zero, relative to the other coefficients
+func solveCubic(a, b, c, d float64) []float64 {
+	scale := math.Max(math.Max(math.Abs(a), math.Abs(b)), math.Max(math.Abs(c), math.Abs(d)))
+	if scale == 0 {
+		return nil
+	}
+	if math.Abs(a) < epsilon*scale {
+		return solveQuadratic(b, c, d)
+	}
+
+	// depressed cubic t^3 + p*t + q = 0, where x = t - b/(3a)
+	b, c, d = b/a, c/a, d/a
+	p := c - b*b/3
+	q := 2*b*b*b/27 - b*c/3 + d
+	offset := -b / 3
+
+	discriminant := q*q/4 + p*p*p/27
+	switch {
+	case math.Abs(discriminant) < epsilon*epsilon:
+		// multiple roots
+		if math.Abs(p) < epsilon {
+			return []float64{offset}
+		}
+		u := math.Cbrt(-q / 2)
+		return []float64{2*u + offset, -u + offset}
+	case discriminant > 0:
+		// one real root (Cardano)
+		sqrt := math.Sqrt(discriminant)
+		return []float64{math.Cbrt(-q/2+sqrt) + math.Cbrt(-q/2-sqrt) + offset}
+	default:
+		// three real roots (trigonometric method)
+		r := 2 * math.Sqrt(-p/3)
+		phi := math.Acos(mathf.Clamp(3*q/(p*r), -1, 1)) / 3
+		return []float64{
+			r*math.Cos(phi) + offset,
+			r*math.Cos(phi-2*math.Pi/3) + offset,
+			r*math.Cos(phi-4*math.Pi/3) + offset,
+		}
+	}
+}
+
+// bernsteinToPower converts the coefficients of a cubic polynomial in the Bernstein basis
+// into the power basis (a*t^3 + b*t^2 + c*t + d)
+func bernsteinToPower(p0, p1, p2, p3 float64) (float64, float64, float64, float64) {
+	return -p0 + 3*p1 - 3*p2 + p3, 3*p0 - 6*p1 + 3*p2, -3*p0 + 3*p1, p0
+}
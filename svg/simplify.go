@@ -0,0 +1,220 @@
+package svg
+
+// For more information on polyline simplification:
+// - https://en.wikipedia.org/wiki/Ramer%E2%80%93Douglas%E2%80%93Peucker_algorithm
+// - https://en.wikipedia.org/wiki/Visvalingam%E2%80%93Whyatt_algorithm
+
+import (
+	"container/heap"
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// SimplificationMethod represents an algorithm removing the points of a polyline that are not needed
+type SimplificationMethod int
+
+const (
+	// NoSimplification keeps every point
+	NoSimplification SimplificationMethod = iota
+	// DouglasPeucker (Ramer–Douglas–Peucker) keeps the points further than the tolerance (a distance)
+	// from the simplified polyline
+	DouglasPeucker
+	// VisvalingamWhyatt removes the points whose triangle with their neighbours has an area below the tolerance,
+	// starting with the smallest
+	VisvalingamWhyatt
+	// CollinearMerge removes the points where the polyline turns by less than the tolerance (an angle, in radians)
+	CollinearMerge
+)
+
+// SimplifyPolyline returns the polyline without the points that are not needed according to the method and tolerance
+// the first and last points are always kept
+func SimplifyPolyline(points []vector.Vector2, method SimplificationMethod, tolerance float64) []vector.Vector2 {
+	if len(points) < 3 || tolerance <= 0 {
+		return points
+	}
+
+	switch method {
+	case DouglasPeucker:
+		return douglasPeucker(points, tolerance)
+	case VisvalingamWhyatt:
+		return visvalingamWhyatt(points, tolerance)
+	case CollinearMerge:
+		return collinearMerge(points, tolerance)
+	}
+	return points
+}
+
+// Simplify returns a copy of the path whose runs of consecutive straight lines are simplified as polylines
+// curves are kept as they are, unless they were flattened (see Flatten and ParserOptions.FlatteningTolerance)
+func (p Path) Simplify(method SimplificationMethod, tolerance float64) Path {
+	if method == NoSimplification || tolerance <= 0 {
+		return p
+	}
+
+	p.Data = mapLineRuns(p.Data, func(points []vector.Vector2) []PathData {
+		simplified := SimplifyPolyline(points, method, tolerance)
+		data := make([]PathData, 0, len(simplified)-1)
+		for i := 1; i < len(simplified); i++ {
+			data = append(data, newLine(simplified[i-1], simplified[i]))
+		}
+		return data
+	})
+
+	return p
+}
+
+// mapLineRuns returns the path data with every run of consecutive, connected, straight lines
+// replaced by the path data the function returns for its points; curves are kept as they are
+func mapLineRuns(data []PathData, replace func(points []vector.Vector2) []PathData) []PathData {
+	var mapped []PathData
+	var run []vector.Vector2
+	flush := func() {
+		if len(run) > 0 {
+			mapped = append(mapped, replace(run)...)
+		}
+		run = nil
+	}
+
+	for _, d := range data {
+		switch {
+		case d.IsLine() && len(run) > 0 && run[len(run)-1] == d.Start:
+			run = append(run, d.End)
+		case d.IsLine():
+			flush()
+			run = []vector.Vector2{d.Start, d.End}
+		default:
+			flush()
+			mapped = append(mapped, d)
+		}
+	}
+	flush()
+
+	return mapped
+}
+
+// douglasPeucker keeps the points further than the tolerance from the chords of the simplified polyline,
+// splitting the polyline at the furthest point until every point is within the tolerance
+func douglasPeucker(points []vector.Vector2, tolerance float64) []vector.Vector2 {
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	// ranges still to be simplified, iteratively to support long polylines
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		furthest, distance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := distanceToSegment(points[i], points[first], points[last]); d > distance {
+				furthest, distance = i, d
+			}
+		}
+		if furthest >= 0 {
+			keep[furthest] = true
+			stack = append(stack, [2]int{first, furthest}, [2]int{furthest, last})
+		}
+	}
+
+	var simplified []vector.Vector2
+	for i, point := range points {
+		if keep[i] {
+			simplified = append(simplified, point)
+		}
+	}
+
+	return simplified
+}
+
+// areaItem represents a point of a polyline with the area of its triangle, in the heap of the Visvalingam–Whyatt algorithm
+type areaItem struct {
+	index int
+	area  float64
+}
+
+// areaHeap is a min-heap of points by area
+type areaHeap []areaItem
+
+func (h areaHeap) Len() int            { return len(h) }
+func (h areaHeap) Less(i, j int) bool  { return h[i].area < h[j].area }
+func (h areaHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *areaHeap) Push(x interface{}) { *h = append(*h, x.(areaItem)) }
+func (h *areaHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// visvalingamWhyatt repeatedly removes the point whose triangle with its neighbours has the smallest area,
+// while that area is below the tolerance
+func visvalingamWhyatt(points []vector.Vector2, tolerance float64) []vector.Vector2 {
+	n := len(points)
+	previous, next := make([]int, n), make([]int, n)
+	areas := make([]float64, n)
+	removed := make([]bool, n)
+	triangleArea := func(i int) float64 {
+		a, b, c := points[previous[i]], points[i], points[next[i]]
+		return 0.5 * math.Abs(b.Sub(a).Cross(c.Sub(a)))
+	}
+
+	h := make(areaHeap, 0, n)
+	for i := range points {
+		previous[i], next[i] = i-1, i+1
+	}
+	for i := 1; i < n-1; i++ {
+		areas[i] = triangleArea(i)
+		h = append(h, areaItem{index: i, area: areas[i]})
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		item := heap.Pop(&h).(areaItem)
+		// skips the outdated entries of the points removed or updated since they were pushed
+		if removed[item.index] || item.area != areas[item.index] {
+			continue
+		}
+		if item.area >= tolerance {
+			break
+		}
+
+		i := item.index
+		removed[i] = true
+		next[previous[i]], previous[next[i]] = next[i], previous[i]
+
+		// the neighbours never get a smaller area than the removed point, so that they are removed after it
+		for _, neighbour := range [...]int{previous[i], next[i]} {
+			if neighbour > 0 && neighbour < n-1 {
+				areas[neighbour] = math.Max(triangleArea(neighbour), item.area)
+				heap.Push(&h, areaItem{index: neighbour, area: areas[neighbour]})
+			}
+		}
+	}
+
+	var simplified []vector.Vector2
+	for i, point := range points {
+		if !removed[i] {
+			simplified = append(simplified, point)
+		}
+	}
+
+	return simplified
+}
+
+// collinearMerge removes the points where the direction of the polyline, from the last kept point,
+// changes by less than the tolerance angle
+func collinearMerge(points []vector.Vector2, tolerance float64) []vector.Vector2 {
+	simplified := []vector.Vector2{points[0]}
+	for i := 1; i < len(points)-1; i++ {
+		in := points[i].Sub(simplified[len(simplified)-1])
+		out := points[i+1].Sub(points[i])
+		angle := math.Abs(math.Atan2(in.Cross(out), in.Dot(out)))
+		// repeated points have no direction and are removed as well
+		if angle >= tolerance && in.MagnitudeSqr() > 0 && out.MagnitudeSqr() > 0 {
+			simplified = append(simplified, points[i])
+		}
+	}
+
+	return append(simplified, points[len(points)-1])
+}
@@ -0,0 +1,117 @@
+package svg
+
+import (
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Split splits the curve at t using de Casteljau's algorithm, returning the parts before and after t
+func (p PathData) Split(t float64) (PathData, PathData) {
+	a := vector.LerpUnclamped(p.Start, p.Control[0], t)
+	b := vector.LerpUnclamped(p.Control[0], p.Control[1], t)
+	c := vector.LerpUnclamped(p.Control[1], p.End, t)
+	ab := vector.LerpUnclamped(a, b, t)
+	bc := vector.LerpUnclamped(b, c, t)
+	point := vector.LerpUnclamped(ab, bc, t)
+
+	return PathData{Start: p.Start, End: point, Control: [2]vector.Vector2{a, ab}},
+		PathData{Start: point, End: p.End, Control: [2]vector.Vector2{bc, c}}
+}
+
+// SubCurve returns the part of the curve between the parameters t0 and t1
+// when t0 is greater than t1, the returned curve runs backwards
+func (p PathData) SubCurve(t0, t1 float64) PathData {
+	t0, t1 = mathf.Clamp(t0, 0, 1), mathf.Clamp(t1, 0, 1)
+	if t0 > t1 {
+		return p.SubCurve(t1, t0).Reverse()
+	}
+
+	// drops the part before t0
+	_, curve := p.Split(t0)
+	if t0 >= 1 {
+		return curve
+	}
+	// drops the part after t1, whose parameter is rescaled to the remaining curve
+	curve, _ = curve.Split((t1 - t0) / (1 - t0))
+
+	return curve
+}
+
+// Subdivide splits the curve into n parts, each spanning the same parameter range
+func (p PathData) Subdivide(n int) []PathData {
+	if n <= 1 {
+		return []PathData{p}
+	}
+
+	parts := make([]PathData, 0, n)
+	rest := p
+	for i := 0; i < n-1; i++ {
+		// the parameter of the next split, rescaled to the remaining curve
+		var part PathData
+		part, rest = rest.Split(1 / float64(n-i))
+		parts = append(parts, part)
+	}
+
+	return append(parts, rest)
+}
+
+// Reverse returns the same curve, running from its end to its start
+func (p PathData) Reverse() PathData {
+	return PathData{
+		Start:   p.End,
+		End:     p.Start,
+		Control: [2]vector.Vector2{p.Control[1], p.Control[0]},
+	}
+}
+
+// Reverse returns a copy of the path, running from its end to its start
+func (p Path) Reverse() Path {
+	data := make([]PathData, len(p.Data))
+	for i, d := range p.Data {
+		data[len(p.Data)-1-i] = d.Reverse()
+	}
+	p.Data = data
+
+	return p
+}
+
+// Trim returns a copy of the path containing only the part between the given fractions of its length,
+// in the range [0, 1], like the trim paths of Lottie animations; the fractions are swapped if start is greater than end
+func (p Path) Trim(start, end float64) Path {
+	start, end = mathf.Clamp(start, 0, 1), mathf.Clamp(end, 0, 1)
+	if start > end {
+		start, end = end, start
+	}
+
+	table := NewArcLengthTable(p, defaultArcLengthPrecision)
+	return table.Section(start*table.Length(), end*table.Length())
+}
+
+// Section returns a copy of the path containing only the part between the given distances along it
+func (a ArcLengthTable) Section(from, to float64) Path {
+	section := a.path
+	section.Data = nil
+	if to <= from || len(a.path.Data) == 0 {
+		return section
+	}
+
+	first, t0 := a.Lookup(from)
+	last, t1 := a.Lookup(to)
+	for i := first; i <= last; i++ {
+		start, end := 0.0, 1.0
+		if i == first {
+			start = t0
+		}
+		if i == last {
+			end = t1
+		}
+		// skips the degenerate parts at the boundaries of the segments
+		if end-start < epsilon {
+			continue
+		}
+
+		section.Data = append(section.Data, a.path.Data[i].SubCurve(start, end))
+	}
+
+	return section
+}
@@ -0,0 +1,183 @@
+package svg
+
+// For more information on stroking:
+// - https://www.w3.org/TR/SVG2/painting.html#StrokeProperties
+
+import (
+	"strconv"
+	"strings"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// LineCap represents the shape at the ends of open subpaths
+type LineCap int
+
+const (
+	// ButtCap ends the stroke exactly at the endpoints
+	ButtCap LineCap = iota
+	// RoundCap extends the stroke with a half circle
+	RoundCap
+	// SquareCap extends the stroke by half its width
+	SquareCap
+)
+
+// stroke properties
+const (
+	strokeWidthProperty      = "stroke-width"
+	strokeLineJoinProperty   = "stroke-linejoin"
+	strokeLineCapProperty    = "stroke-linecap"
+	strokeMiterLimitProperty = "stroke-miterlimit"
+	pixelUnit                = "px"
+	// default stroke width, as in SVG
+	defaultStrokeWidth = 1
+)
+
+// line join and line cap values
+var (
+	lineJoinValues = map[string]LineJoin{
+		"miter":      MiterJoin,
+		"miter-clip": MiterJoin,
+		"arcs":       MiterJoin,
+		"round":      RoundJoin,
+		"bevel":      BevelJoin,
+	}
+	lineCapValues = map[string]LineCap{
+		"butt":   ButtCap,
+		"round":  RoundCap,
+		"square": SquareCap,
+	}
+)
+
+// StrokeStyle represents the properties that shape the stroke of a path
+type StrokeStyle struct {
+	// width of the stroke, centred on the path
+	Width float64
+	// shape used at the corners of the path
+	Join LineJoin
+	// shape used at the ends of open subpaths
+	Cap LineCap
+	// maximum ratio between the miter length and the width, beyond which miter joins are bevelled
+	MiterLimit float64
+}
+
+// StrokeStyle returns the stroke properties of the path, as defined by its "stroke-width", "stroke-linejoin",
+// "stroke-linecap" and "stroke-miterlimit" properties
+// missing or invalid properties take the SVG defaults (width 1, miter joins, butt caps and miter limit 4)
+func (p Path) StrokeStyle() StrokeStyle {
+	style := StrokeStyle{Width: defaultStrokeWidth, Join: MiterJoin, Cap: ButtCap, MiterLimit: defaultMiterLimit}
+
+	if value, ok := p.Attributes.Property(strokeWidthProperty); ok {
+		if width, err := strconv.ParseFloat(strings.TrimSuffix(value, pixelUnit), 64); err == nil && width >= 0 {
+			style.Width = width
+		}
+	}
+	if value, ok := p.Attributes.Property(strokeLineJoinProperty); ok {
+		if join, ok := lineJoinValues[value]; ok {
+			style.Join = join
+		}
+	}
+	if value, ok := p.Attributes.Property(strokeLineCapProperty); ok {
+		if lineCap, ok := lineCapValues[value]; ok {
+			style.Cap = lineCap
+		}
+	}
+	if value, ok := p.Attributes.Property(strokeMiterLimitProperty); ok {
+		if limit, err := strconv.ParseFloat(value, 64); err == nil && limit >= 1 {
+			style.MiterLimit = limit
+		}
+	}
+
+	return style
+}
+
+// Outline returns the outline of the stroke of the path, as paths to be filled with the NonZero rule
+// every subpath returns its own path: open subpaths are outlined by a single contour, with caps at their ends,
+// while closed subpaths are outlined by two contours, one on each side and in opposite directions
+// the curves are offset within the given tolerance (zero or less uses the default, 0.1);
+// the outlines keep the identification of the path, but not its attributes
+func (p Path) Outline(style StrokeStyle, tolerance float64) []Path {
+	if style.Width <= 0 {
+		return nil
+	}
+	options := OffsetOptions{Join: style.Join, MiterLimit: style.MiterLimit, Tolerance: tolerance}.withDefaults()
+	halfWidth := 0.5 * style.Width
+
+	var outlines []Path
+	for _, subpath := range p.Subpaths() {
+		outline := Path{ID: p.ID, Label: p.Label, Layer: p.Layer}
+		start := subpath.Data[0].Start
+		// the segments without length have no direction, so the joins and caps follow the segments around them
+		subpath = subpath.withoutDegenerateSegments()
+		switch {
+		case len(subpath.Data) == 0:
+			outline.Data = dotOutline(start, halfWidth, style.Cap)
+		case subpath.IsClosed():
+			outline.Data = append(
+				subpath.offsetSubpath(halfWidth, options, true),
+				subpath.Reverse().offsetSubpath(halfWidth, options, true)...,
+			)
+		default:
+			reversed := subpath.Reverse()
+			last, first := subpath.Data[len(subpath.Data)-1], reversed.Data[len(reversed.Data)-1]
+			outline.Data = subpath.offsetSubpath(halfWidth, options, true)
+			outline.Data = append(outline.Data, capCurves(last.End, last.Tangent(1), halfWidth, style.Cap)...)
+			outline.Data = append(outline.Data, reversed.offsetSubpath(halfWidth, options, true)...)
+			outline.Data = append(outline.Data, capCurves(first.End, first.Tangent(1), halfWidth, style.Cap)...)
+		}
+
+		if len(outline.Data) > 0 {
+			outlines = append(outlines, outline)
+		}
+	}
+
+	return outlines
+}
+
+// capCurves returns the cap at the end of a subpath, with the given tangent, from its left side to its right side
+func capCurves(end, tangent vector.Vector2, halfWidth float64, lineCap LineCap) []PathData {
+	normal := tangent.Left().Mul(halfWidth)
+	from, to := end.Add(normal), end.Sub(normal)
+
+	switch lineCap {
+	case RoundCap:
+		return arcCurves(end, from, to, true)
+	case SquareCap:
+		extension := tangent.Mul(halfWidth)
+		return []PathData{
+			newLine(from, from.Add(extension)),
+			newLine(from.Add(extension), to.Add(extension)),
+			newLine(to.Add(extension), to),
+		}
+	}
+
+	return []PathData{newLine(from, to)}
+}
+
+// dotOutline returns the outline of a subpath without length, which is only visible with round or square caps
+func dotOutline(point vector.Vector2, halfWidth float64, lineCap LineCap) []PathData {
+	switch lineCap {
+	case RoundCap:
+		from := point.Add(vector.Vector2{X: halfWidth})
+		// two half circles, since a full circle has no sweep between equal endpoints
+		return append(
+			arcCurves(point, from, point.Sub(vector.Vector2{X: halfWidth}), false),
+			arcCurves(point, point.Sub(vector.Vector2{X: halfWidth}), from, false)...,
+		)
+	case SquareCap:
+		// like in browsers, the square is aligned with the axes
+		corners := [...]vector.Vector2{
+			{X: point.X - halfWidth, Y: point.Y - halfWidth},
+			{X: point.X + halfWidth, Y: point.Y - halfWidth},
+			{X: point.X + halfWidth, Y: point.Y + halfWidth},
+			{X: point.X - halfWidth, Y: point.Y + halfWidth},
+		}
+		data := make([]PathData, len(corners))
+		for i, corner := range corners {
+			data[i] = newLine(corner, corners[(i+1)%len(corners)])
+		}
+		return data
+	}
+
+	return nil
+}
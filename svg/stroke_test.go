@@ -0,0 +1,138 @@
+package svg
+
+import (
+	"encoding/xml"
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// outlinesContain checks if any of the outlines contains the point, as they are filled
+func outlinesContain(outlines []Path, point vector.Vector2) bool {
+	for _, outline := range outlines {
+		if outline.Contains(point, NonZero) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOutlineClosedSquare(t *testing.T) {
+	tests := []struct {
+		join    LineJoin
+		inside  []vector.Vector2
+		outside []vector.Vector2
+	}{
+		{MiterJoin, []vector.Vector2{{X: -0.5, Y: -0.5}, {X: -0.9, Y: -0.9}, {X: 10.9, Y: 10.9}}, []vector.Vector2{{X: -1.1, Y: -0.5}}},
+		{RoundJoin, []vector.Vector2{{X: -0.5, Y: -0.5}, {X: -0.6, Y: -0.6}, {X: 10.6, Y: 10.6}}, []vector.Vector2{{X: -0.8, Y: -0.8}}},
+		{BevelJoin, []vector.Vector2{{X: -0.4, Y: -0.4}, {X: 10.4, Y: 10.4}}, []vector.Vector2{{X: -0.6, Y: -0.6}}},
+	}
+	for spelling, data := range closedSquares {
+		square := parseSinglePath(t, data)
+		for _, test := range tests {
+			style := StrokeStyle{Width: 2, Join: test.join, MiterLimit: defaultMiterLimit}
+			outlines := square.Outline(style, 0.01)
+			if len(outlines) != 1 {
+				t.Fatalf("%s: got %d outlines, expected one", spelling, len(outlines))
+			}
+
+			// every corner is joined alike, and the stroke is a ring around the inside of the square
+			for _, point := range append(test.inside, vector.Vector2{X: 0.5, Y: 5}, vector.Vector2{X: 5, Y: 10.5}) {
+				if !outlinesContain(outlines, point) {
+					t.Errorf("%s, join %d: the outline does not contain %v", spelling, test.join, point)
+				}
+			}
+			for _, point := range append(test.outside, vector.Vector2{X: 5, Y: 5}, vector.Vector2{X: 1.5, Y: 1.5}) {
+				if outlinesContain(outlines, point) {
+					t.Errorf("%s, join %d: the outline contains %v", spelling, test.join, point)
+				}
+			}
+		}
+	}
+}
+
+func TestOutlineCaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		cap     LineCap
+		area    float64
+		inside  []vector.Vector2
+		outside []vector.Vector2
+	}{
+		{"butt", "M0 0 L10 0", ButtCap, 20, []vector.Vector2{{X: 0.1, Y: 0.9}}, []vector.Vector2{{X: -0.1}, {X: 10.1}}},
+		{"square", "M0 0 L10 0", SquareCap, 24, []vector.Vector2{{X: -0.9, Y: 0.9}, {X: 10.9, Y: -0.9}}, []vector.Vector2{{X: -1.1}}},
+		{"round", "M0 0 L10 0", RoundCap, 20 + math.Pi, []vector.Vector2{{X: -0.9}, {X: 10.9}}, []vector.Vector2{{X: -0.9, Y: 0.9}}},
+		// the redundant line at the end does not turn the cap
+		{"square after a zero-length line", "M0 0 L10 0 L10 0", SquareCap, 24, []vector.Vector2{{X: 10.9, Y: 0.9}}, []vector.Vector2{{X: 9, Y: 1.1}}},
+		{"round dot", "M5 5 L5 5", RoundCap, math.Pi, []vector.Vector2{{X: 5.9, Y: 5}}, []vector.Vector2{{X: 5.9, Y: 5.9}}},
+		{"square dot", "M5 5 L5 5", SquareCap, 4, []vector.Vector2{{X: 5.9, Y: 5.9}}, []vector.Vector2{{X: 6.1, Y: 5}}},
+		{"butt dot", "M5 5 L5 5", ButtCap, 0, nil, []vector.Vector2{{X: 5, Y: 5}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outlines := parseSinglePath(t, test.data).Outline(StrokeStyle{Width: 2, Cap: test.cap, MiterLimit: defaultMiterLimit}, 0.001)
+
+			var area float64
+			for _, outline := range outlines {
+				area += outline.Area()
+			}
+			if !approxEqual(math.Abs(area), test.area, 1e-3) {
+				t.Errorf("the outlines enclose %v, expected %v", area, test.area)
+			}
+			for _, point := range test.inside {
+				if !outlinesContain(outlines, point) {
+					t.Errorf("the outline does not contain %v", point)
+				}
+			}
+			for _, point := range test.outside {
+				if outlinesContain(outlines, point) {
+					t.Errorf("the outline contains %v", point)
+				}
+			}
+		})
+	}
+}
+
+func TestStrokeStyle(t *testing.T) {
+	attribute := func(name, value string) xml.Attr {
+		return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+	}
+	defaults := StrokeStyle{Width: 1, Join: MiterJoin, Cap: ButtCap, MiterLimit: 4}
+
+	tests := []struct {
+		name       string
+		attributes Attributes
+		style      StrokeStyle
+	}{
+		{"defaults", nil, defaults},
+		{
+			"attributes",
+			Attributes{
+				attribute(strokeWidthProperty, "2.5px"), attribute(strokeLineJoinProperty, "round"),
+				attribute(strokeLineCapProperty, "square"), attribute(strokeMiterLimitProperty, "10"),
+			},
+			StrokeStyle{Width: 2.5, Join: RoundJoin, Cap: SquareCap, MiterLimit: 10},
+		},
+		{"style", Attributes{attribute("style", "stroke-linejoin:bevel;stroke-linecap:round")}, StrokeStyle{Width: 1, Join: BevelJoin, Cap: RoundCap, MiterLimit: 4}},
+		{
+			"invalid values",
+			Attributes{
+				attribute(strokeWidthProperty, "-1"), attribute(strokeLineJoinProperty, "sharp"),
+				attribute(strokeLineCapProperty, "flat"), attribute(strokeMiterLimitProperty, "0.5"),
+			},
+			defaults,
+		},
+	}
+	for _, test := range tests {
+		if style := (Path{Attributes: test.attributes}).StrokeStyle(); style != test.style {
+			t.Errorf("%s: StrokeStyle() = %+v, expected %+v", test.name, style, test.style)
+		}
+	}
+
+	// a stroke without width has no outline
+	if outlines := square(0, 0, 2).Outline(StrokeStyle{}, 0); len(outlines) != 0 {
+		t.Errorf("Outline without width = %v, expected none", outlines)
+	}
+}
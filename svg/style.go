@@ -0,0 +1,36 @@
+package svg
+
+// For more information on styling:
+// - https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/style
+// - https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/Presentation
+
+import "strings"
+
+// style attribute
+const (
+	styleAttribute      = "style"
+	declarationSplitter = ";"
+	propertySplitter    = ":"
+)
+
+// Property returns the value of the given presentation property (e.g. "fill-rule")
+// a declaration in the style attribute takes precedence over the presentation attribute with the same name
+func (a Attributes) Property(name string) (string, bool) {
+	if style, ok := a.Get(styleAttribute); ok {
+		for _, declaration := range strings.Split(style, declarationSplitter) {
+			i := strings.Index(declaration, propertySplitter)
+			if i < 0 || strings.TrimSpace(declaration[:i]) != name {
+				continue
+			}
+
+			value := strings.TrimSpace(declaration[i+1:])
+			// ignores the priority, since there is no cascade
+			value = strings.TrimSpace(strings.TrimSuffix(value, "!important"))
+
+			return value, true
+		}
+	}
+
+	value, ok := a.GetNS("", name)
+	return strings.TrimSpace(value), ok
+}
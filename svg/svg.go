@@ -2,6 +2,7 @@ package svg
 
 import (
 	"encoding/xml"
+	"io"
 
 	"github.com/mindera-gaming/go-math/mathf"
 )
@@ -12,77 +13,200 @@ const (
 	pathElementTag  = "path"
 )
 
-// svg represents the structure of an SVG file
-type svg struct {
-	XMLName  xml.Name  `xml:"svg"`
-	Elements []element `xml:",any"`
-}
-
-// groupElement represents a group element
-type groupElement struct {
-	XMLName  xml.Name  `xml:"g"`
-	Elements []element `xml:",any"`
-}
-
 // element represents an SVG element
 type element struct {
-	XMLName xml.Name
-	ID      string `xml:"id,attr"`
-	Data    []byte `xml:"d,attr"`
-	Value   []byte `xml:",innerxml"`
+	XMLName  xml.Name
+	Attrs    []xml.Attr
+	Elements []element
 }
 
 // Path represents a customised path structure
 type Path struct {
 	// ID contains the identifier of a set of paths
 	ID string
+	// Label contains the name given to the path by Inkscape
+	Label string
+	// Layer contains the path of the layer the path belongs to (e.g. "Collision/Platforms")
+	Layer string
+	// Attributes contains every attribute of the path element
+	Attributes Attributes
 	// Data contains a set of paths
 	Data []PathData
+	// Clip contains the clipping paths of the path and of its groups, in the coordinates of the path;
+	// the path is only visible inside every one of them (see ApplyClip)
+	Clip []Path
+}
+
+// Group represents a group element and the elements it contains
+type Group struct {
+	// ID contains the identifier of the group
+	ID string
+	// Label contains the name given to the group by Inkscape
+	Label string
+	// Layer contains the path of the layer the group belongs to, including itself if it is a layer
+	Layer string
+	// IsLayer tells whether the group is an Inkscape layer
+	IsLayer bool
+	// Attributes contains every attribute of the group element
+	Attributes Attributes
+	// Groups contains the groups nested in this group
+	Groups []Group
+	// Paths contains the paths directly inside this group
+	Paths []Path
 }
 
 // ParserOptions are used to configure the parse of the SVG
 type ParserOptions struct {
 	// tolerance to ignore path nodes that are probably not visible to the naked eye
 	SlopeTolerance float64
+	// layers to parse, matched by name or by layer path; when empty, every layer is parsed
+	IncludeLayers []string
+	// layers to skip, matched by name or by layer path; takes precedence over IncludeLayers
+	ExcludeLayers []string
+	// when greater than zero, curves are replaced by straight lines no further than this tolerance from them
+	FlatteningTolerance float64
+	// when greater than zero, flat curves become straight lines, segments shorter than this tolerance are removed
+	// and collinear lines are merged, within this tolerance (see Path.Cleanup)
+	CleanupTolerance float64
+	// algorithm removing the points of the straight lines that are not needed (see Path.Simplify), applied after
+	// flattening, so that it also simplifies the flattened curves
+	Simplification SimplificationMethod
+	// tolerance of the simplification: a distance for DouglasPeucker, an area for VisvalingamWhyatt
+	// and an angle, in radians, for CollinearMerge
+	SimplificationTolerance float64
+	// maximum size, in bytes, of decompressed SVGZ data and of the data read by the Reader variants;
+	// zero uses the default (64 MiB) and a negative value disables it
+	MaxDecompressedSize int64
+	// maximum number of elements in the document; zero uses the default (100000) and a negative value disables it
+	MaxElements int
+	// maximum nesting depth of the elements; zero uses the default (256) and a negative value disables it
+	MaxDepth int
+	// maximum length of a "d" attribute; zero uses the default (1 MiB) and a negative value disables it
+	MaxPathDataLength int
+	// maximum number of path segments in the document; zero uses the default (1000000) and a negative value disables it
+	MaxSegments int
+	// maximum magnitude of a coordinate; zero uses the default (1e9) and a negative value disables it
+	MaxCoordinate float64
+	// when true, the paths are intersected with their clipping paths (see Path.ApplyClip) instead of keeping them
+	// in Path.Clip; the clipped paths and the clipping paths are flattened within FlatteningTolerance, or the default (0.1),
+	// except the clipping paths in objectBoundingBox units, which are flattened within 0.1% of the bounding box
+	ExactClipping bool
 }
 
 // ParsePath deserialises the SVG data and returns a set of paths
+// gzip-compressed (SVGZ) data is decompressed transparently
 func ParsePath(data []byte, options ParserOptions) ([]Path, error) {
-	// unmarshalling the xml data
-	svg := svg{}
-	if err := xml.Unmarshal(data, &svg); err != nil {
+	paths, _, err := parse(data, options)
+	return paths, err
+}
+
+// ParsePathReader deserialises the SVG data read from the given reader and returns a set of paths
+// gzip-compressed (SVGZ) data is decompressed transparently
+func ParsePathReader(r io.Reader, options ParserOptions) ([]Path, error) {
+	data, err := readAll(r, options)
+	if err != nil {
 		return nil, err
 	}
 
+	// the data was already decompressed while reading it
+	paths, _, err := parseDecompressed(data, options)
+	return paths, err
+}
+
+// ParseDocument deserialises the SVG data and returns the root group of the document,
+// keeping the hierarchy of groups and layers
+// gzip-compressed (SVGZ) data is decompressed transparently
+func ParseDocument(data []byte, options ParserOptions) (Group, error) {
+	_, root, err := parse(data, options)
+	return root, err
+}
+
+// ParseDocumentReader deserialises the SVG data read from the given reader and returns the root group of the document
+// gzip-compressed (SVGZ) data is decompressed transparently
+func ParseDocumentReader(r io.Reader, options ParserOptions) (Group, error) {
+	data, err := readAll(r, options)
+	if err != nil {
+		return Group{}, err
+	}
+
+	// the data was already decompressed while reading it
+	_, root, err := parseDecompressed(data, options)
+	return root, err
+}
+
+// parse deserialises the SVG data and returns both the set of paths and the root group
+func parse(data []byte, options ParserOptions) ([]Path, Group, error) {
+	// decompressing the data, if needed
+	data, err := decompress(data, options)
+	if err != nil {
+		return nil, Group{}, err
+	}
+
+	return parseDecompressed(data, options)
+}
+
+// parseDecompressed deserialises the decompressed SVG data and returns both the set of paths and the root group
+func parseDecompressed(data []byte, options ParserOptions) ([]Path, Group, error) {
+	// decoding the xml data
+	limits := newLimits(options)
+	svg, err := decode(data, limits)
+	if err != nil {
+		return nil, Group{}, err
+	}
+
 	// validates the tolerance value
 	// it can never be less than zero
 	options.SlopeTolerance = mathf.Max(0, options.SlopeTolerance)
 
-	return parseElements(svg.Elements, options)
+	root := Group{Attributes: newAttributes(svg.Attrs)}
+	state := parserState{Options: options, Limits: limits, ClipPaths: make(map[string]element)}
+	// clipping paths can be referenced before being defined
+	collectClipPaths(svg.Elements, state.ClipPaths)
+	paths, err := state.parseElements(svg.Elements, &root, len(options.IncludeLayers) == 0, nil)
+	if err != nil {
+		return nil, Group{}, err
+	}
+
+	return paths, root, nil
 }
 
-// parseElements deserialises a SVG element
-func parseElements(elements []element, options ParserOptions) ([]Path, error) {
+// parserState holds the options and the progress of a parse
+type parserState struct {
+	Options ParserOptions
+	Limits  limits
+	// number of path segments parsed so far
+	Segments int
+	// clipping path definitions, by identifier
+	ClipPaths map[string]element
+	// content of the clipping paths parsed so far, by identifier
+	Clips map[string]Path
+	// path data parsed ahead of its element to compute the bounding box of a group, by element
+	PathData map[*element][]PathData
+	// bounding boxes of the groups computed so far, by element
+	Bounds map[*element]Box
+}
+
+// parseElements deserialises a set of SVG elements into the given group, clipped by the given clipping paths
+// paths are only kept if they are included by the layer filters
+func (s *parserState) parseElements(elements []element, group *Group, included bool, clips []Path) ([]Path, error) {
 	var paths []Path
-	for _, e := range elements {
+	for i := range elements {
+		e := &elements[i]
 		var err error
 		var newPaths []Path
 
 		switch e.XMLName.Local {
 		case groupElementTag:
-			newPaths, err = parseGroup(e.Value, options)
+			newPaths, err = s.parseGroup(e, group, included, clips)
 		case pathElementTag:
-			path := path{
-				ID:   string(e.ID),
-				Data: string(e.Data),
+			if !included {
+				continue
 			}
-			path.Clean()
-			var pathData []PathData
-			pathData, err = path.Parse(options)
-			newPaths = append(newPaths, Path{
-				ID:   path.ID,
-				Data: pathData,
-			})
+
+			var newPath Path
+			newPath, err = s.parsePathElement(e, group, clips)
+			newPaths = append(newPaths, newPath)
+			group.Paths = append(group.Paths, newPath)
 		}
 
 		if err != nil {
@@ -96,14 +220,165 @@ func parseElements(elements []element, options ParserOptions) ([]Path, error) {
 	return paths, nil
 }
 
-// parseGroup deserialises an element of type group
-func parseGroup(group []byte, options ParserOptions) ([]Path, error) {
-	group = append([]byte("<g>"), group...)
-	group = append(group, []byte("</g>")...)
-	g := groupElement{}
-	if err := xml.Unmarshal(group, &g); err != nil {
+// parsePathElement deserialises an element of type path, in the given group, clipped by the given clipping paths
+func (s *parserState) parsePathElement(e *element, group *Group, clips []Path) (Path, error) {
+	pathData, err := s.parsePathOnce(e)
+	if err != nil {
+		return Path{}, err
+	}
+
+	newPath := Path{
+		ID:         e.ID(),
+		Label:      e.Label(),
+		Layer:      group.Layer,
+		Attributes: newAttributes(e.Attrs),
+		Data:       pathData,
+	}
+	newPath.Clip, err = s.appendClip(clips, *e, func() (Box, error) { return newPath.Bounds(), nil })
+	if err != nil {
+		return Path{}, err
+	}
+	if s.Options.ExactClipping && len(newPath.Clip) > 0 {
+		if newPath, err = s.applyClip(newPath); err != nil {
+			return Path{}, err
+		}
+	}
+
+	return newPath, nil
+}
+
+// parsePathOnce deserialises the data of an element of type path (see parsePath), unless it was already parsed
+// to compute the bounding box of a group, in which case the parsed data is returned, without counting it again
+func (s *parserState) parsePathOnce(e *element) ([]PathData, error) {
+	if pathData, ok := s.PathData[e]; ok {
+		delete(s.PathData, e)
+		return pathData, nil
+	}
+
+	return s.parsePath(*e)
+}
+
+// parsePath deserialises the data of an element of type path, within the resource limits
+func (s *parserState) parsePath(e element) ([]PathData, error) {
+	path := path{
+		ID:   e.ID(),
+		Data: e.Data(),
+	}
+	if exceeds(len(path.Data), s.Limits.PathDataLength) {
+		return nil, newLimitExceededError(pathDataLengthLimit, float64(s.Limits.PathDataLength))
+	}
+
+	path.Clean()
+	pathData, err := path.Parse(s.Options)
+	if err != nil {
+		return nil, err
+	}
+	// the coordinates are validated before any processing, which would otherwise work on huge or non-finite values
+	if err := validateCoordinates(pathData, s.Limits.Coordinate); err != nil {
+		return nil, err
+	}
+	// removes the segments that are not needed, if requested
+	if s.Options.CleanupTolerance > 0 {
+		pathData = CleanupPathData(pathData, s.Options.CleanupTolerance)
+	}
+	// replaces the curves by straight lines, if requested
+	if s.Options.FlatteningTolerance > 0 {
+		if pathData, err = s.flatten(pathData, s.Options.FlatteningTolerance); err != nil {
+			return nil, err
+		}
+	}
+	// removes the points that are not needed, if requested
+	if s.Options.Simplification != NoSimplification {
+		pathData = Path{Data: pathData}.Simplify(s.Options.Simplification, s.Options.SimplificationTolerance).Data
+	}
+
+	if err := s.countSegments(len(pathData)); err != nil {
+		return nil, err
+	}
+
+	return pathData, nil
+}
+
+// flatten replaces the curves of the path data by straight lines within the tolerance, without exceeding
+// the segments left by the segment limit; the flattening stops as soon as the limit is exceeded
+func (s *parserState) flatten(data []PathData, tolerance float64) ([]PathData, error) {
+	maxLines := -1
+	if s.Limits.Segments >= 0 {
+		maxLines = s.Limits.Segments - s.Segments
+	}
+
+	flattened, ok := Path{Data: data}.flatten(tolerance, maxLines)
+	if !ok {
+		return nil, newLimitExceededError(segmentCountLimit, float64(s.Limits.Segments))
+	}
+
+	return flattened.Data, nil
+}
+
+// reflatten replaces the curves of path data whose segments were already counted by straight lines (see flatten),
+// counting the lines instead of the segments
+func (s *parserState) reflatten(data []PathData, tolerance float64) ([]PathData, error) {
+	s.Segments -= len(data)
+	flattened, err := s.flatten(data, tolerance)
+	if err != nil {
 		return nil, err
 	}
 
-	return parseElements(g.Elements, options)
+	return flattened, s.countSegments(len(flattened))
+}
+
+// countSegments adds the given number of segments to the ones parsed so far, checking the segment limit
+func (s *parserState) countSegments(segments int) error {
+	s.Segments += segments
+	if exceeds(s.Segments, s.Limits.Segments) {
+		return newLimitExceededError(segmentCountLimit, float64(s.Limits.Segments))
+	}
+
+	return nil
+}
+
+// parseGroup deserialises an element of type group and adds it to its parent
+func (s *parserState) parseGroup(e *element, parent *Group, included bool, clips []Path) ([]Path, error) {
+	group := Group{
+		ID:         e.ID(),
+		Label:      e.Label(),
+		Layer:      parent.Layer,
+		IsLayer:    isLayer(*e),
+		Attributes: newAttributes(e.Attrs),
+	}
+
+	var excluded bool
+	group.Layer, included, excluded = s.groupLayer(*e, parent.Layer, included)
+	// excluded layers are skipped along with all of their content
+	if excluded {
+		return nil, nil
+	}
+
+	clips, err := s.appendClip(clips, *e, func() (Box, error) { return s.elementBounds(e, group.Layer, included) })
+	if err != nil {
+		return nil, err
+	}
+	paths, err := s.parseElements(e.Elements, &group, included, clips)
+	if err != nil {
+		return nil, err
+	}
+	parent.Groups = append(parent.Groups, group)
+
+	return paths, nil
+}
+
+// groupLayer returns the layer the group belongs to, given the one of its parent, and whether its content is included
+// by the layer filters, given whether the content of its parent is; excluded tells if the group is an excluded layer
+func (s *parserState) groupLayer(e element, parentLayer string, parentIncluded bool) (layer string, included, excluded bool) {
+	if !isLayer(e) {
+		return parentLayer, parentIncluded, false
+	}
+
+	name := layerName(e)
+	layer = joinLayer(parentLayer, name)
+	if matchLayer(s.Options.ExcludeLayers, name, layer) {
+		return layer, false, true
+	}
+	// the content of an included layer is always included
+	return layer, parentIncluded || matchLayer(s.Options.IncludeLayers, name, layer), false
 }
@@ -0,0 +1,300 @@
+package svg
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// strokeVertex represents the vertices on both sides of the stroke, at a vertex of the flattened path
+type strokeVertex struct {
+	left, right int
+}
+
+// strokeTessellator holds the state of the tessellation of a stroke
+type strokeTessellator struct {
+	mesh      Mesh
+	style     StrokeStyle
+	halfWidth float64
+	tolerance float64
+}
+
+// TessellateStroke returns a triangle mesh covering the stroke of the path, with the given style
+// the curves are flattened within the given tolerance (zero or less uses the default, 0.1); every segment becomes
+// a quad, the corners get the joins of the style and the ends of open subpaths get its caps
+// the UVs of the vertices have U along the path, as the distance from the start of the subpath (extended
+// backwards and forwards by the caps), and V across the stroke, from 0 on the left edge to 1 on the right edge
+func (p Path) TessellateStroke(style StrokeStyle, tolerance float64) Mesh {
+	if style.Width <= 0 {
+		return Mesh{}
+	}
+	if style.MiterLimit <= 0 {
+		style.MiterLimit = defaultMiterLimit
+	}
+	if tolerance <= 0 {
+		tolerance = defaultFlatteningTolerance
+	}
+
+	t := strokeTessellator{style: style, halfWidth: 0.5 * style.Width, tolerance: tolerance}
+	for _, polyline := range p.Polylines(tolerance) {
+		// skips the edges without length
+		points := polyline[:1]
+		for _, point := range polyline[1:] {
+			if point != points[len(points)-1] {
+				points = append(points, point)
+			}
+		}
+
+		closed := len(points) > 2 && points[0] == points[len(points)-1]
+		switch {
+		case len(points) < 2:
+			t.dot(points[0])
+		case closed:
+			t.polyline(points[:len(points)-1], true)
+		default:
+			t.polyline(points, false)
+		}
+	}
+
+	return t.mesh
+}
+
+// vertex adds a vertex with the given position and texture coordinates, returning its index
+func (t *strokeTessellator) vertex(position vector.Vector2, u, v float64) int {
+	t.mesh.Vertices = append(t.mesh.Vertices, position)
+	t.mesh.UVs = append(t.mesh.UVs, vector.Vector2{X: u, Y: v})
+	return len(t.mesh.Vertices) - 1
+}
+
+// localVertex adds a vertex around the given point of the path, with texture coordinates given by its offset
+// along the direction of the path and across it, returning its index
+func (t *strokeTessellator) localVertex(position, center, direction vector.Vector2, distance float64) int {
+	offset := position.Sub(center)
+	u := distance + offset.Dot(direction)
+	v := 0.5 - 0.5*offset.Dot(direction.Left())/t.halfWidth
+	return t.vertex(position, u, v)
+}
+
+// triangle adds a triangle, in counter-clockwise order
+func (t *strokeTessellator) triangle(a, b, c int) {
+	vertices := t.mesh.Vertices
+	if vertices[b].Sub(vertices[a]).Cross(vertices[c].Sub(vertices[a])) < 0 {
+		b, c = c, b
+	}
+	t.mesh.Indices = append(t.mesh.Indices, a, b, c)
+}
+
+// arcSteps returns the number of edges approximating a circular arc of the stroke with the given angle
+func (t *strokeTessellator) arcSteps(angle float64) int {
+	// maximum angle of an edge no further than the tolerance from the arc
+	step := 0.5 * math.Pi
+	if t.tolerance < t.halfWidth {
+		step = math.Min(step, 2*math.Acos(1-t.tolerance/t.halfWidth))
+	}
+	return int(math.Max(1, math.Ceil(math.Abs(angle)/step)))
+}
+
+// fan adds a triangle fan around the center, along the circular arc from one point to another
+// with the given signed angle (positive when counter-clockwise)
+func (t *strokeTessellator) fan(center, from vector.Vector2, angle float64, vertex func(vector.Vector2) int) {
+	centerIndex := vertex(center)
+	radius := from.Sub(center)
+	steps := t.arcSteps(angle)
+
+	previous := vertex(from)
+	for i := 1; i <= steps; i++ {
+		sin, cos := math.Sincos(angle * float64(i) / float64(steps))
+		point := center.Add(vector.Vector2{X: radius.X*cos - radius.Y*sin, Y: radius.X*sin + radius.Y*cos})
+		current := vertex(point)
+		t.triangle(centerIndex, previous, current)
+		previous = current
+	}
+}
+
+// polyline tessellates the stroke of a polyline without repeated points
+func (t *strokeTessellator) polyline(points []vector.Vector2, closed bool) {
+	n := len(points)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	directions := make([]vector.Vector2, segments)
+	lengths := make([]float64, segments)
+	distances := make([]float64, segments+1)
+	for k := range directions {
+		offset := points[(k+1)%n].Sub(points[k])
+		lengths[k] = offset.Magnitude()
+		directions[k] = offset.Mul(1 / lengths[k])
+		distances[k+1] = distances[k] + lengths[k]
+	}
+
+	// vertices at the end of the incoming segment and at the start of the outgoing segment of every point
+	in, out := make([]strokeVertex, n), make([]strokeVertex, n)
+	for i, point := range points {
+		switch {
+		case closed && i == 0:
+			// the closing point ends the path, at its full length, and starts it again, at zero
+			in[i], out[i] = t.join(point, directions[segments-1], directions[0], lengths[segments-1], lengths[0], distances[segments], 0)
+		case i == 0:
+			out[i] = t.end(point, directions[0], 0, false)
+		case !closed && i == n-1:
+			in[i] = t.end(point, directions[i-1], distances[i], true)
+		default:
+			in[i], out[i] = t.join(point, directions[i-1], directions[i], lengths[i-1], lengths[i], distances[i], distances[i])
+		}
+	}
+
+	// every segment is a quad between its start and end vertices
+	for k := 0; k < segments; k++ {
+		start, end := out[k], in[(k+1)%n]
+		t.triangle(start.right, end.right, end.left)
+		t.triangle(start.right, end.left, start.left)
+	}
+}
+
+// end returns the vertices at an end of an open polyline, with the given direction of the path, adding its cap
+func (t *strokeTessellator) end(point, direction vector.Vector2, distance float64, last bool) strokeVertex {
+	normal := direction.Left().Mul(t.halfWidth)
+	vertex := func(position vector.Vector2) int {
+		return t.localVertex(position, point, direction, distance)
+	}
+	sides := strokeVertex{left: vertex(point.Add(normal)), right: vertex(point.Sub(normal))}
+
+	// the caps point outwards, backwards at the start of the path
+	outwards := direction.Mul(t.halfWidth)
+	if !last {
+		outwards = outwards.Mul(-1)
+	}
+	switch t.style.Cap {
+	case RoundCap:
+		angle := math.Pi
+		if last {
+			angle = -math.Pi
+		}
+		t.fan(point, point.Add(normal), angle, vertex)
+	case SquareCap:
+		left, right := vertex(point.Add(normal).Add(outwards)), vertex(point.Sub(normal).Add(outwards))
+		t.triangle(sides.left, sides.right, right)
+		t.triangle(sides.left, right, left)
+	}
+
+	return sides
+}
+
+// join returns the vertices at a corner of the polyline, at the end of the incoming segment and at the start of
+// the outgoing one, adding the join on the outer side of the corner; the distances along the path are usually equal,
+// except at the closing point of closed polylines
+func (t *strokeTessellator) join(point, directionIn, directionOut vector.Vector2, lengthIn, lengthOut, distanceIn, distanceOut float64) (strokeVertex, strokeVertex) {
+	normalIn, normalOut := directionIn.Left(), directionOut.Left()
+	// adds the vertices of both segments, sharing the ones with the same position and texture coordinates
+	sides := func(leftIn, rightIn, leftOut, rightOut vector.Vector2) (strokeVertex, strokeVertex) {
+		in := strokeVertex{left: t.vertex(leftIn, distanceIn, 0), right: t.vertex(rightIn, distanceIn, 1)}
+		out := in
+		if leftOut != leftIn || distanceOut != distanceIn {
+			out.left = t.vertex(leftOut, distanceOut, 0)
+		}
+		if rightOut != rightIn || distanceOut != distanceIn {
+			out.right = t.vertex(rightOut, distanceOut, 1)
+		}
+		return in, out
+	}
+
+	turn := directionIn.Cross(directionOut)
+	if math.Abs(turn) < epsilon && directionIn.Dot(directionOut) > 0 {
+		// straight through, both segments share their vertices
+		normal := normalIn.Mul(t.halfWidth)
+		return sides(point.Add(normal), point.Sub(normal), point.Add(normal), point.Sub(normal))
+	}
+
+	// the inner side is the one the path turns to, on the left when turning counter-clockwise
+	inner := 1.0
+	if turn < 0 {
+		inner = -1
+	}
+	outerIn := point.Sub(normalIn.Mul(inner * t.halfWidth))
+	outerOut := point.Sub(normalOut.Mul(inner * t.halfWidth))
+	innerIn := point.Add(normalIn.Mul(inner * t.halfWidth))
+	innerOut := point.Add(normalOut.Mul(inner * t.halfWidth))
+
+	// the offset edges meet on the inner side, along the bisector of the normals, unless they are too short
+	// when the path turns back on itself, there is no bisector and the outer corner is bevelled
+	var outwards vector.Vector2
+	if sum := normalIn.Add(normalOut); sum.Magnitude() > epsilon {
+		bisector := sum.Normalized()
+		outwards = bisector.Mul(-inner)
+
+		meeting := point.Add(bisector.Mul(inner * t.halfWidth / bisector.Dot(normalIn)))
+		offset := meeting.Sub(point)
+		if math.Abs(offset.Dot(directionIn)) <= lengthIn && math.Abs(offset.Dot(directionOut)) <= lengthOut {
+			innerIn, innerOut = meeting, meeting
+		}
+	}
+
+	t.joinCorner(point, outerIn, outerOut, outwards, distanceIn, inner)
+	if innerIn == innerOut {
+		// the segments end at their meeting point instead of the point of the path, leaving a gap up to the join
+		v := 0.5 - 0.5*inner
+		meeting, center := t.vertex(innerIn, distanceIn, v), t.vertex(point, distanceIn, 0.5)
+		t.triangle(meeting, t.vertex(outerIn, distanceIn, 1-v), center)
+		t.triangle(meeting, center, t.vertex(outerOut, distanceIn, 1-v))
+	}
+	if inner > 0 {
+		return sides(innerIn, outerIn, innerOut, outerOut)
+	}
+	return sides(outerIn, innerIn, outerOut, innerOut)
+}
+
+// joinCorner adds the join on the outer side of a corner, between the outer vertices of both segments
+func (t *strokeTessellator) joinCorner(point, outerIn, outerOut, outwards vector.Vector2, distance, inner float64) {
+	// the outer side is the left one (V = 0) when the path turns clockwise
+	v := 0.0
+	if inner > 0 {
+		v = 1
+	}
+	vertex := func(position vector.Vector2) int {
+		if position == point {
+			return t.vertex(position, distance, 0.5)
+		}
+		return t.vertex(position, distance, v)
+	}
+
+	switch t.style.Join {
+	case RoundJoin:
+		from, to := outerIn.Sub(point), outerOut.Sub(point)
+		t.fan(point, outerIn, math.Atan2(from.Cross(to), from.Dot(to)), vertex)
+		return
+	case MiterJoin:
+		// the miter length, relative to the width, is the inverse of the cosine of half the turn
+		if cos := outwards.Dot(outerIn.Sub(point)) / t.halfWidth; cos > epsilon && 1/cos <= t.style.MiterLimit {
+			tip := vertex(point.Add(outwards.Mul(t.halfWidth / cos)))
+			center := vertex(point)
+			t.triangle(center, vertex(outerIn), tip)
+			t.triangle(center, tip, vertex(outerOut))
+			return
+		}
+	}
+
+	t.triangle(vertex(point), vertex(outerIn), vertex(outerOut))
+}
+
+// dot tessellates a subpath without length, which is only visible with round or square caps
+func (t *strokeTessellator) dot(point vector.Vector2) {
+	direction := vector.Vector2{X: 1}
+	vertex := func(position vector.Vector2) int {
+		return t.localVertex(position, point, direction, 0)
+	}
+
+	switch t.style.Cap {
+	case RoundCap:
+		t.fan(point, point.Add(vector.Vector2{X: t.halfWidth}), 2*math.Pi, vertex)
+	case SquareCap:
+		h := t.halfWidth
+		a := vertex(vector.Vector2{X: point.X - h, Y: point.Y - h})
+		b := vertex(vector.Vector2{X: point.X + h, Y: point.Y - h})
+		c := vertex(vector.Vector2{X: point.X + h, Y: point.Y + h})
+		d := vertex(vector.Vector2{X: point.X - h, Y: point.Y + h})
+		t.triangle(a, b, c)
+		t.triangle(a, c, d)
+	}
+}
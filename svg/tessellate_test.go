@@ -0,0 +1,100 @@
+package svg
+
+import (
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+func TestTessellateStrokeLine(t *testing.T) {
+	line := Path{Data: []PathData{newLine(vector.Vector2{}, vector.Vector2{X: 10})}}
+
+	tests := []struct {
+		name string
+		cap  LineCap
+		area float64
+		// range of U, extended by the caps
+		minU, maxU float64
+	}{
+		{"butt", ButtCap, 20, 0, 10},
+		{"square", SquareCap, 24, -1, 11},
+		{"round", RoundCap, 20 + math.Pi, -1, 11},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mesh := line.TessellateStroke(StrokeStyle{Width: 2, Cap: test.cap}, 0.001)
+			if area := meshArea(t, mesh); !approxEqual(area, test.area, 0.01) {
+				t.Errorf("the area of the mesh is %v, expected %v", area, test.area)
+			}
+			if len(mesh.UVs) != len(mesh.Vertices) {
+				t.Fatalf("got %d UVs for %d vertices", len(mesh.UVs), len(mesh.Vertices))
+			}
+
+			minU, maxU := math.Inf(1), math.Inf(-1)
+			for i, vertex := range mesh.Vertices {
+				// U is the distance along the line and V goes from the left edge (y = 1) to the right one (y = -1)
+				uv := mesh.UVs[i]
+				if !approxEqual(uv.X, vertex.X, 1e-9) || !approxEqual(uv.Y, 0.5-0.5*vertex.Y, 1e-9) {
+					t.Errorf("the vertex %v has the UV %v", vertex, uv)
+				}
+				minU, maxU = math.Min(minU, uv.X), math.Max(maxU, uv.X)
+			}
+			if !approxEqual(minU, test.minU, 1e-9) || !approxEqual(maxU, test.maxU, 1e-9) {
+				t.Errorf("U goes from %v to %v, expected %v to %v", minU, maxU, test.minU, test.maxU)
+			}
+		})
+	}
+}
+
+func TestTessellateStrokeArcLength(t *testing.T) {
+	arc := quarterCircle(vector.Vector2{}, 10, 0)
+	path := Path{Data: []PathData{arc}}
+	length := arc.Length()
+
+	for _, join := range []LineJoin{MiterJoin, RoundJoin, BevelJoin} {
+		mesh := path.TessellateStroke(StrokeStyle{Width: 1, Join: join, MiterLimit: defaultMiterLimit}, 0.01)
+		meshArea(t, mesh)
+
+		// U follows the arc length of the point of the curve beside the vertex, so it grows along the curve;
+		// the vertices of a corner share the U of the corner, but lie beside slightly different points of the curve
+		maxU := math.Inf(-1)
+		for i, vertex := range mesh.Vertices {
+			parameter, _, _ := arc.Nearest(vertex)
+			if distance := arc.LengthBetween(0, parameter); !approxEqual(mesh.UVs[i].X, distance, 0.05) {
+				t.Errorf("with the join %v, the vertex %v has U = %v, expected the arc length %v", join, vertex, mesh.UVs[i].X, distance)
+			}
+			maxU = math.Max(maxU, mesh.UVs[i].X)
+		}
+		if !approxEqual(maxU, length, 0.01) {
+			t.Errorf("with the join %v, U ends at %v, expected the length %v", join, maxU, length)
+		}
+	}
+}
+
+func TestTessellateStrokeClosed(t *testing.T) {
+	// the closing point ends the square, at its full length, and starts it again, at zero
+	mesh := square(0, 0, 10).TessellateStroke(StrokeStyle{Width: 2, Join: MiterJoin, MiterLimit: defaultMiterLimit}, 0)
+	meshArea(t, mesh)
+
+	var start, end bool
+	for i, uv := range mesh.UVs {
+		if uv.X < -1e-9 || uv.X > 40+1e-9 {
+			t.Errorf("the vertex %v has U = %v, outside the length of the square", mesh.Vertices[i], uv.X)
+		}
+		start = start || uv.X == 0
+		end = end || uv.X == 40
+	}
+	if !start || !end {
+		t.Errorf("U starts at zero: %v, and ends at the length: %v", start, end)
+	}
+
+	// the stroke of a point is only visible with round or square caps
+	dot := Path{Data: []PathData{newLine(vector.Vector2{X: 1, Y: 1}, vector.Vector2{X: 1, Y: 1})}}
+	if mesh := dot.TessellateStroke(StrokeStyle{Width: 2}, 0); mesh.Triangles() != 0 {
+		t.Errorf("the butt dot has %d triangles", mesh.Triangles())
+	}
+	if area := meshArea(t, dot.TessellateStroke(StrokeStyle{Width: 2, Cap: SquareCap}, 0)); !approxEqual(area, 4, 1e-9) {
+		t.Errorf("the area of the square dot is %v, expected 4", area)
+	}
+}
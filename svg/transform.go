@@ -0,0 +1,174 @@
+package svg
+
+// For more information on transformations:
+// - https://developer.mozilla.org/en-US/docs/Web/SVG/Attribute/transform
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Transform represents an affine transformation, with the same layout as the SVG matrix(a, b, c, d, e, f):
+//
+//	x' = a*x + c*y + e
+//	y' = b*x + d*y + f
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// Identity returns the transformation that leaves every point unchanged
+func Identity() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// Translate returns a translation by the given offsets
+func Translate(x, y float64) Transform {
+	return Transform{A: 1, D: 1, E: x, F: y}
+}
+
+// Scale returns a scale by the given factors
+func Scale(x, y float64) Transform {
+	return Transform{A: x, D: y}
+}
+
+// Rotate returns a rotation by the given angle, in radians
+func Rotate(angle float64) Transform {
+	sin, cos := math.Sincos(angle)
+	return Transform{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Multiply returns the transformation that applies the given transformation first and this one afterwards
+func (t Transform) Multiply(other Transform) Transform {
+	return Transform{
+		A: t.A*other.A + t.C*other.B,
+		B: t.B*other.A + t.D*other.B,
+		C: t.A*other.C + t.C*other.D,
+		D: t.B*other.C + t.D*other.D,
+		E: t.A*other.E + t.C*other.F + t.E,
+		F: t.B*other.E + t.D*other.F + t.F,
+	}
+}
+
+// Apply returns the given point transformed
+func (t Transform) Apply(point vector.Vector2) vector.Vector2 {
+	return vector.Vector2{
+		X: t.A*point.X + t.C*point.Y + t.E,
+		Y: t.B*point.X + t.D*point.Y + t.F,
+	}
+}
+
+// Transform returns the path data transformed
+// since Bézier curves are affine invariant, transforming the control points is exact
+func (p PathData) Transform(t Transform) PathData {
+	return PathData{
+		Start:   t.Apply(p.Start),
+		End:     t.Apply(p.End),
+		Control: [2]vector.Vector2{t.Apply(p.Control[0]), t.Apply(p.Control[1])},
+	}
+}
+
+// Transform returns a copy of the path with its data transformed
+func (p Path) Transform(t Transform) Path {
+	data := make([]PathData, len(p.Data))
+	for i, d := range p.Data {
+		data[i] = d.Transform(t)
+	}
+	p.Data = data
+
+	return p
+}
+
+// transform functions
+const (
+	matrixFunction    = "matrix"
+	translateFunction = "translate"
+	scaleFunction     = "scale"
+	rotateFunction    = "rotate"
+	skewXFunction     = "skewX"
+	skewYFunction     = "skewY"
+)
+
+// ParseTransform parses the value of a "transform" attribute (e.g. "translate(10, 20) rotate(45)")
+// the transformations are applied from right to left, as in SVG; angles are in degrees
+func ParseTransform(value string) (Transform, error) {
+	t := Identity()
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		end := strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return Identity(), newInvalidTransformError(value)
+		}
+
+		arguments, ok := parseTransformArguments(rest[open+1 : end])
+		if !ok {
+			return Identity(), newInvalidTransformError(value)
+		}
+		function, ok := transformFunction(strings.TrimSpace(rest[:open]), arguments)
+		if !ok {
+			return Identity(), newInvalidTransformError(value)
+		}
+
+		t = t.Multiply(function)
+		rest = strings.TrimLeft(rest[end+1:], " \t\r\n,")
+	}
+
+	return t, nil
+}
+
+// parseTransformArguments parses the arguments of a transform function, separated by commas or whitespace
+func parseTransformArguments(value string) ([]float64, bool) {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+
+	arguments := make([]float64, len(fields))
+	for i, field := range fields {
+		argument, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsNaN(argument) || math.IsInf(argument, 0) {
+			return nil, false
+		}
+		arguments[i] = argument
+	}
+
+	return arguments, true
+}
+
+// transformFunction returns the transformation of a transform function with the given arguments
+func transformFunction(name string, arguments []float64) (Transform, bool) {
+	radians := func(degrees float64) float64 {
+		return degrees * math.Pi / 180
+	}
+
+	switch {
+	case name == matrixFunction && len(arguments) == 6:
+		return Transform{
+			A: arguments[0], B: arguments[1],
+			C: arguments[2], D: arguments[3],
+			E: arguments[4], F: arguments[5],
+		}, true
+	case name == translateFunction && len(arguments) == 1:
+		return Translate(arguments[0], 0), true
+	case name == translateFunction && len(arguments) == 2:
+		return Translate(arguments[0], arguments[1]), true
+	case name == scaleFunction && len(arguments) == 1:
+		return Scale(arguments[0], arguments[0]), true
+	case name == scaleFunction && len(arguments) == 2:
+		return Scale(arguments[0], arguments[1]), true
+	case name == rotateFunction && len(arguments) == 1:
+		return Rotate(radians(arguments[0])), true
+	case name == rotateFunction && len(arguments) == 3:
+		// rotation around the point (cx, cy)
+		cx, cy := arguments[1], arguments[2]
+		return Translate(cx, cy).Multiply(Rotate(radians(arguments[0]))).Multiply(Translate(-cx, -cy)), true
+	case name == skewXFunction && len(arguments) == 1:
+		return Transform{A: 1, C: math.Tan(radians(arguments[0])), D: 1}, true
+	case name == skewYFunction && len(arguments) == 1:
+		return Transform{A: 1, B: math.Tan(radians(arguments[0])), D: 1}, true
+	}
+
+	return Transform{}, false
+}
@@ -0,0 +1,273 @@
+package svg
+
+// For more information on triangulation by ear clipping:
+// - https://www.geometrictools.com/Documentation/TriangulationByEarClipping.pdf
+
+import (
+	"math"
+	"sort"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Mesh represents an indexed triangle mesh
+type Mesh struct {
+	// Vertices contains the positions of the vertices
+	Vertices []vector.Vector2
+	// Indices contains three indices of Vertices per triangle, in counter-clockwise order (in a y-up coordinate system)
+	Indices []int
+	// UVs contains the texture coordinates of the vertices, when generated (see Path.TessellateStroke)
+	UVs []vector.Vector2
+}
+
+// Triangles returns the number of triangles of the mesh
+func (m Mesh) Triangles() int {
+	return len(m.Indices) / 3
+}
+
+// addVertex returns the index of the vertex at the given position, adding it if needed
+func (m *Mesh) addVertex(position vector.Vector2, indices map[vector.Vector2]int) int {
+	if i, ok := indices[position]; ok {
+		return i
+	}
+
+	indices[position] = len(m.Vertices)
+	m.Vertices = append(m.Vertices, position)
+
+	return len(m.Vertices) - 1
+}
+
+// Triangulate returns a triangle mesh covering the fill of the path, according to its fill rule
+// the curves are flattened within the given tolerance (zero or less uses the default, 0.1); the contours are
+// normalised first (see Boolean), so self-intersections, overlaps and holes are resolved by the fill rule,
+// then every outer contour is bridged to its holes and triangulated by ear clipping
+func (p Path) Triangulate(tolerance float64) Mesh {
+	shape := newPolygon(p, p.FillRule(), tolerance)
+	contours := combineContours(shape, polygon{}, UnionOperation, p.Bounds().Size().Magnitude())
+
+	// outer contours are counter-clockwise and holes clockwise
+	var outers, holes [][]vector.Vector2
+	for _, contour := range contours {
+		if polygonArea(contour) > 0 {
+			outers = append(outers, contour)
+		} else {
+			holes = append(holes, contour)
+		}
+	}
+
+	mesh := Mesh{}
+	indices := make(map[vector.Vector2]int)
+	for i, outer := range outers {
+		for _, triangle := range earClip(bridgeHoles(outer, ownHoles(i, outers, holes))) {
+			for _, vertex := range triangle {
+				mesh.Indices = append(mesh.Indices, mesh.addVertex(vertex, indices))
+			}
+		}
+	}
+
+	return mesh
+}
+
+// polygonArea returns the signed area of the polygon, positive when counter-clockwise
+func polygonArea(points []vector.Vector2) float64 {
+	var area float64
+	for i, point := range points {
+		area += point.Cross(points[(i+1)%len(points)])
+	}
+	return 0.5 * area
+}
+
+// ownHoles returns the holes directly inside the outer contour with the given index,
+// i.e. the holes whose smallest enclosing outer contour is that one
+func ownHoles(index int, outers, holes [][]vector.Vector2) [][]vector.Vector2 {
+	var own [][]vector.Vector2
+	for _, hole := range holes {
+		// a point just outside the hole, on the filled side of its first edge, is inside the enclosing contour
+		offset := hole[1].Sub(hole[0]).Left().Mul(probePrecision)
+		point := vector.LerpUnclamped(hole[0], hole[1], 0.5).Add(offset)
+
+		owner, ownerArea := -1, math.Inf(1)
+		for i, outer := range outers {
+			if area := polygonArea(outer); area < ownerArea && polygonContains(outer, point) {
+				owner, ownerArea = i, area
+			}
+		}
+		if owner == index {
+			own = append(own, hole)
+		}
+	}
+
+	return own
+}
+
+// polygonContains checks if the point is inside the simple polygon
+func polygonContains(points []vector.Vector2, point vector.Vector2) bool {
+	edges := appendPolyline(nil, append(append([]vector.Vector2(nil), points...), points[0]))
+	return polygon{edges: edges, rule: EvenOdd}.contains(point)
+}
+
+// bridgeHoles merges the holes into the outer contour, connecting each one to a visible vertex by a pair of
+// coincident edges, which results in a single, weakly simple, polygon
+func bridgeHoles(outer []vector.Vector2, holes [][]vector.Vector2) []vector.Vector2 {
+	// the holes are bridged from right to left, so that the bridges never cross the holes yet to be bridged
+	rightmost := make([]int, len(holes))
+	for i, hole := range holes {
+		for j, point := range hole {
+			if point.X > hole[rightmost[i]].X {
+				rightmost[i] = j
+			}
+		}
+	}
+	order := make([]int, len(holes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(m, n int) bool {
+		return holes[order[m]][rightmost[order[m]]].X > holes[order[n]][rightmost[order[n]]].X
+	})
+
+	merged := append([]vector.Vector2(nil), outer...)
+	for _, i := range order {
+		hole, m := holes[i], rightmost[i]
+		visible := visibleVertex(merged, hole[m])
+		if visible < 0 {
+			continue
+		}
+
+		// outer up to the visible vertex, the whole hole from its rightmost vertex and back to the visible vertex
+		bridged := append([]vector.Vector2(nil), merged[:visible+1]...)
+		bridged = append(bridged, hole[m:]...)
+		bridged = append(bridged, hole[:m+1]...)
+		bridged = append(bridged, merged[visible:]...)
+		merged = bridged
+	}
+
+	return merged
+}
+
+// visibleVertex returns the index of a vertex of the polygon visible from the point inside it, found by casting a ray
+// towards +x, or a negative index if there is none
+func visibleVertex(points []vector.Vector2, point vector.Vector2) int {
+	// finds the nearest edge hit by the ray
+	edgeIndex, hitX := -1, math.Inf(1)
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		if a.Y == b.Y || point.Y < math.Min(a.Y, b.Y) || point.Y > math.Max(a.Y, b.Y) {
+			continue
+		}
+
+		x := a.X + (point.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x >= point.X && x < hitX {
+			edgeIndex, hitX = i, x
+		}
+	}
+	if edgeIndex < 0 {
+		return -1
+	}
+
+	// the endpoint of the edge with the largest x is a candidate
+	a, b := edgeIndex, (edgeIndex+1)%len(points)
+	candidate := a
+	if points[b].X > points[a].X {
+		candidate = b
+	}
+	hit := vector.Vector2{X: hitX, Y: point.Y}
+	if points[candidate] == hit {
+		return candidate
+	}
+
+	// a reflex vertex inside the triangle between the point, the hit and the candidate may hide the candidate,
+	// in which case the one with the smallest angle to the ray is visible
+	best, bestAngle, bestDistance := candidate, math.Inf(1), math.Inf(1)
+	for i, vertex := range points {
+		previous, next := points[(i+len(points)-1)%len(points)], points[(i+1)%len(points)]
+		if i == candidate || vertex.Sub(previous).Cross(next.Sub(vertex)) >= 0 ||
+			!pointInTriangle(vertex, point, hit, points[candidate]) {
+			continue
+		}
+
+		offset := vertex.Sub(point)
+		angle := math.Abs(math.Atan2(offset.Y, offset.X))
+		if distance := offset.Magnitude(); angle < bestAngle || (angle == bestAngle && distance < bestDistance) {
+			best, bestAngle, bestDistance = i, angle, distance
+		}
+	}
+
+	return best
+}
+
+// pointInTriangle checks if the point is inside the triangle or on its edges, regardless of its orientation
+func pointInTriangle(point, a, b, c vector.Vector2) bool {
+	ab := b.Sub(a).Cross(point.Sub(a))
+	bc := c.Sub(b).Cross(point.Sub(b))
+	ca := a.Sub(c).Cross(point.Sub(c))
+
+	return (ab >= 0 && bc >= 0 && ca >= 0) || (ab <= 0 && bc <= 0 && ca <= 0)
+}
+
+// earClip triangulates the counter-clockwise, weakly simple, polygon by clipping its ears,
+// returning counter-clockwise triangles; collinear vertices are removed without triangles
+func earClip(points []vector.Vector2) [][3]vector.Vector2 {
+	n := len(points)
+	if n < 3 {
+		return nil
+	}
+
+	// doubly linked list of the remaining vertices
+	previous, next := make([]int, n), make([]int, n)
+	for i := range points {
+		previous[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+	remove := func(i int) {
+		next[previous[i]], previous[next[i]] = next[i], previous[i]
+		n--
+	}
+
+	var triangles [][3]vector.Vector2
+	// number of vertices visited since the last one was removed, which detects when no ear is left
+	current, visited := 0, 0
+	for n > 2 {
+		a, b, c := points[previous[current]], points[current], points[next[current]]
+		// the cross product, relative to the lengths of the edges, is the sine of the turn at any scale
+		turn := b.Sub(a).Cross(c.Sub(b))
+
+		switch {
+		case math.Abs(turn) <= epsilon*a.Distance(b)*b.Distance(c):
+			// collinear vertices and spikes do not add area
+			remove(current)
+		case turn > 0 && isEar(points, previous, next, current):
+			triangles = append(triangles, [3]vector.Vector2{a, b, c})
+			remove(current)
+		case visited > n && turn > 0:
+			// rounding errors can leave no ear at all, in which case a convex vertex is clipped anyway
+			triangles = append(triangles, [3]vector.Vector2{a, b, c})
+			remove(current)
+		case visited > 2*n:
+			// only reflex vertices are left, which cannot be triangulated
+			return triangles
+		default:
+			current = next[current]
+			visited++
+			continue
+		}
+
+		current = previous[current]
+		visited = 0
+	}
+
+	return triangles
+}
+
+// isEar checks if the triangle formed by the vertex and its neighbours contains no other vertex of the polygon
+// the vertices coincident with the ones of the triangle, such as the ends of the bridges to the holes, are ignored
+func isEar(points []vector.Vector2, previous, next []int, vertex int) bool {
+	a, b, c := points[previous[vertex]], points[vertex], points[next[vertex]]
+	for i := next[next[vertex]]; i != previous[vertex]; i = next[i] {
+		point := points[i]
+		if point != a && point != b && point != c && pointInTriangle(point, a, b, c) {
+			return false
+		}
+	}
+
+	return true
+}
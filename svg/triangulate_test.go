@@ -0,0 +1,86 @@
+package svg
+
+import (
+	"encoding/xml"
+	"math"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// meshArea returns the sum of the signed areas of the triangles of the mesh
+func meshArea(t *testing.T, mesh Mesh) float64 {
+	t.Helper()
+	var area float64
+	for i := 0; i < len(mesh.Indices); i += 3 {
+		triangle := polygonArea([]vector.Vector2{
+			mesh.Vertices[mesh.Indices[i]], mesh.Vertices[mesh.Indices[i+1]], mesh.Vertices[mesh.Indices[i+2]],
+		})
+		if triangle < 0 {
+			t.Errorf("triangle %d is clockwise", i/3)
+		}
+		area += triangle
+	}
+	return area
+}
+
+func TestTriangulate(t *testing.T) {
+	// the hole is clockwise, so the signed area of the path is the filled one
+	withHole := square(0, 0, 4)
+	withHole.Data = append(withHole.Data, square(1, 1, 2).Reverse().Data...)
+	// with the even-odd fill rule, the direction of the hole does not matter
+	evenOdd := square(0, 0, 4)
+	evenOdd.Data = append(evenOdd.Data, square(1, 1, 2).Data...)
+	evenOdd.Attributes = Attributes{{Name: xml.Name{Local: fillRuleProperty}, Value: evenOddValue}}
+	// two holes and an island inside one of them
+	islands := square(0, 0, 10)
+	islands.Data = append(islands.Data, square(1, 1, 3).Reverse().Data...)
+	islands.Data = append(islands.Data, square(5, 5, 3).Reverse().Data...)
+	islands.Data = append(islands.Data, square(6, 6, 1).Data...)
+
+	tests := []struct {
+		name string
+		path Path
+		area float64
+	}{
+		{"square", square(0, 0, 2), 4},
+		{"clockwise square", square(0, 0, 2).Reverse(), 4},
+		{"square with a hole", withHole, withHole.Area()},
+		{"even-odd hole", evenOdd, 12},
+		{"islands", islands, islands.Area()},
+		{"concave", polygonPath(vector.Vector2{}, vector.Vector2{X: 4}, vector.Vector2{X: 2, Y: 1}, vector.Vector2{X: 4, Y: 4}, vector.Vector2{Y: 4}), 12},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mesh := test.path.Triangulate(0)
+			if area := meshArea(t, mesh); !approxEqual(area, math.Abs(test.area), 1e-9) {
+				t.Errorf("the area of the mesh is %v, expected %v", area, math.Abs(test.area))
+			}
+		})
+	}
+
+	// the area of a flattened circle approaches the one of the circle
+	if area := meshArea(t, circle(vector.Vector2{}, 10).Triangulate(0.01)); !approxEqual(area, 100*math.Pi, 0.5) {
+		t.Errorf("the area of the circle mesh is %v, expected %v", area, 100*math.Pi)
+	}
+}
+
+func TestTriangulateScale(t *testing.T) {
+	// a concave polygon is triangulated the same way at any scale, with no vertex taken as collinear
+	points := []vector.Vector2{{}, {X: 2, Y: 0.1}, {X: 4}, {X: 2, Y: 1}, {X: 4, Y: 4}, {Y: 4}}
+	for _, scale := range []float64{1e-6, 1, 1e6} {
+		scaled := make([]vector.Vector2, len(points))
+		for i, point := range points {
+			scaled[i] = point.Mul(scale)
+		}
+		path := polygonPath(scaled...)
+
+		mesh := path.Triangulate(0)
+		if area := meshArea(t, mesh); !approxEqual(area/(scale*scale), path.Area()/(scale*scale), 1e-9) {
+			t.Errorf("at the scale %v, the area of the mesh is %v, expected %v", scale, area, path.Area())
+		}
+		if len(mesh.Indices) != 3*(len(points)-2) {
+			t.Errorf("at the scale %v, got %d triangles, expected %d", scale, len(mesh.Indices)/3, len(points)-2)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package svg
+
+// Struct tags have the form `svg:"key=value,key=value"` and accept the following keys:
+// - id: selects the elements whose identifier matches the given pattern (e.g. "spawn-*")
+// - label: selects the elements whose Inkscape label matches the given pattern
+// - layer: selects the elements whose layer path matches the given pattern
+// - element: the kind of elements selected, "path" (the default) or "group"
+// - attr: the attribute holding the value of a basic field (defaults to "id")
+// Patterns follow the syntax of path.Match. Fields without selectors refer to the current element.
+
+import (
+	glob "path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// struct tag keys
+const (
+	tagName     = "svg"
+	tagIgnore   = "-"
+	tagID       = "id"
+	tagLabel    = "label"
+	tagLayer    = "layer"
+	tagElement  = "element"
+	tagAttr     = "attr"
+	defaultAttr = "id"
+)
+
+// kinds of elements selected by the struct tags
+const (
+	pathElement  = "path"
+	groupElement = "group"
+)
+
+// supported geometry types
+var (
+	pathType       = reflect.TypeOf(Path{})
+	boxType        = reflect.TypeOf(Box{})
+	attributesType = reflect.TypeOf(Attributes{})
+)
+
+// fieldTag represents a parsed struct tag
+type fieldTag struct {
+	ID, Label, Layer string
+	Element          string
+	Attr             string
+}
+
+// selects tells whether the tag selects elements or refers to the current one
+func (t fieldTag) selects() bool {
+	return t.ID != "" || t.Label != "" || t.Layer != "" || t.Element != ""
+}
+
+// matches checks if the given node matches the selectors of the tag
+// only paths are selected, unless the tag selects groups
+func (t fieldTag) matches(n node) bool {
+	return n.Group == (t.Element == groupElement) &&
+		matchPattern(t.ID, n.Path.ID) && matchPattern(t.Label, n.Path.Label) && matchPattern(t.Layer, n.Path.Layer)
+}
+
+// node represents an element of the document (group or path) that can be unmarshalled
+type node struct {
+	// Path contains the element information and geometry; for groups, the geometry of all of their paths
+	Path Path
+	// Group tells whether the element is a group
+	Group    bool
+	Children []node
+}
+
+// newGroupNode creates and returns the node of a group and of all of its descendants
+func newGroupNode(group Group) node {
+	n := node{
+		Path: Path{
+			ID:         group.ID,
+			Label:      group.Label,
+			Layer:      group.Layer,
+			Attributes: group.Attributes,
+		},
+		Group: true,
+	}
+	for _, p := range group.Paths {
+		n.Path.Data = append(n.Path.Data, p.Data...)
+		n.Children = append(n.Children, node{Path: p})
+	}
+	for _, g := range group.Groups {
+		child := newGroupNode(g)
+		n.Path.Data = append(n.Path.Data, child.Path.Data...)
+		n.Children = append(n.Children, child)
+	}
+
+	return n
+}
+
+// descendants returns all the descendants of the node, depth-first
+func (n node) descendants() []node {
+	var nodes []node
+	for _, child := range n.Children {
+		nodes = append(nodes, child)
+		nodes = append(nodes, child.descendants()...)
+	}
+
+	return nodes
+}
+
+// Unmarshal deserialises the SVG data and stores the result in the struct pointed to by v,
+// filling its fields according to their "svg" struct tags
+//
+// Supported field types are Path, Box, Attributes, strings, numbers, booleans, structs
+// (filled from the selected element) and slices of any of these (filled with every selected element)
+func Unmarshal(data []byte, v interface{}, options ParserOptions) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return newInvalidUnmarshalError(reflect.TypeOf(v))
+	}
+
+	root, err := ParseDocument(data, options)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalStruct(value.Elem(), newGroupNode(root))
+}
+
+// unmarshalStruct fills the tagged fields of a struct from the given node
+func unmarshalStruct(value reflect.Value, n node) error {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		rawTag, ok := field.Tag.Lookup(tagName)
+		// ignores untagged and unexported fields
+		if !ok || rawTag == tagIgnore || field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if t.Name() != "" {
+			name = t.Name() + "." + name
+		}
+		tag, err := parseFieldTag(name, rawTag)
+		if err != nil {
+			return err
+		}
+
+		// the field refers to the current element, unless it selects others
+		nodes := []node{n}
+		if tag.selects() {
+			nodes = nodes[:0]
+			for _, d := range n.descendants() {
+				if tag.matches(d) {
+					nodes = append(nodes, d)
+				}
+			}
+		}
+
+		if err := unmarshalField(value.Field(i), nodes, tag, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshalField fills a field from the given nodes
+// slices receive every node, while other types only receive the first one
+func unmarshalField(value reflect.Value, nodes []node, tag fieldTag, name string) error {
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(value.Type(), len(nodes), len(nodes))
+		for i, n := range nodes {
+			if err := unmarshalValue(slice.Index(i), n, tag, name); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+
+		return nil
+	}
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	return unmarshalValue(value, nodes[0], tag, name)
+}
+
+// unmarshalValue fills a single value from the given node
+func unmarshalValue(value reflect.Value, n node, tag fieldTag, name string) error {
+	switch value.Type() {
+	case pathType:
+		value.Set(reflect.ValueOf(n.Path))
+		return nil
+	case boxType:
+		// elements without geometry have the zero box, instead of the empty one
+		box := n.Path.Bounds()
+		if box.IsEmpty() {
+			box = Box{}
+		}
+		value.Set(reflect.ValueOf(box))
+		return nil
+	case attributesType:
+		value.Set(reflect.ValueOf(n.Path.Attributes))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return unmarshalStruct(value, n)
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		if err := unmarshalValue(elem.Elem(), n, tag, name); err != nil {
+			return err
+		}
+		value.Set(elem)
+
+		return nil
+	}
+
+	return unmarshalAttribute(value, n, tag, name)
+}
+
+// unmarshalAttribute fills a basic value from an attribute of the given node
+// missing attributes and attributes that cannot be parsed leave the value untouched
+func unmarshalAttribute(value reflect.Value, n node, tag fieldTag, name string) error {
+	attribute, ok := n.Path.Attributes.Get(tag.Attr)
+	if !ok {
+		if !isBasicKind(value.Kind()) {
+			return newUnsupportedFieldTypeError(name, value.Type())
+		}
+		return nil
+	}
+	attribute = strings.TrimSpace(attribute)
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(attribute)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(attribute)
+		if err != nil {
+			return newInvalidAttributeError(name, tag.Attr, attribute)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(attribute, 10, value.Type().Bits())
+		if err != nil {
+			return newInvalidAttributeError(name, tag.Attr, attribute)
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(attribute, 10, value.Type().Bits())
+		if err != nil {
+			return newInvalidAttributeError(name, tag.Attr, attribute)
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(attribute, value.Type().Bits())
+		if err != nil {
+			return newInvalidAttributeError(name, tag.Attr, attribute)
+		}
+		value.SetFloat(f)
+	default:
+		return newUnsupportedFieldTypeError(name, value.Type())
+	}
+
+	return nil
+}
+
+// isBasicKind checks if the given kind can hold an attribute value
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseFieldTag parses the "svg" struct tag of the given field
+func parseFieldTag(name, rawTag string) (fieldTag, error) {
+	tag := fieldTag{Attr: defaultAttr}
+	for _, option := range strings.Split(rawTag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value := option, ""
+		if i := strings.Index(option, "="); i >= 0 {
+			key, value = strings.TrimSpace(option[:i]), strings.TrimSpace(option[i+1:])
+		}
+		if value == "" {
+			return fieldTag{}, newInvalidTagError(name, rawTag)
+		}
+
+		switch key {
+		case tagID:
+			tag.ID = value
+		case tagLabel:
+			tag.Label = value
+		case tagLayer:
+			tag.Layer = value
+		case tagElement:
+			if value != pathElement && value != groupElement {
+				return fieldTag{}, newInvalidTagError(name, rawTag)
+			}
+			tag.Element = value
+			continue
+		case tagAttr:
+			tag.Attr = value
+			continue
+		default:
+			return fieldTag{}, newInvalidTagError(name, rawTag)
+		}
+
+		// validates the pattern
+		if _, err := glob.Match(value, ""); err != nil {
+			return fieldTag{}, newInvalidTagError(name, rawTag)
+		}
+	}
+
+	return tag, nil
+}
+
+// matchPattern checks if the given value matches the pattern
+// an empty pattern matches everything
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, _ := glob.Match(pattern, value)
+
+	return matched
+}
@@ -0,0 +1,139 @@
+package svg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// level is the example of the README
+type level struct {
+	Width      float64  `svg:"attr=width"`
+	SpawnTypes []string `svg:"id=spawn-*,attr=data-type"`
+	Triggers   []struct {
+		ID    string `svg:""`
+		Shape Path   `svg:""`
+		Area  Box    `svg:""`
+	} `svg:"layer=Triggers"`
+	Exit  Box   `svg:"id=exit"`
+	Rooms []Box `svg:"label=room-*,element=group"`
+}
+
+const levelDocument = `<svg width="640" xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape">
+	<g id="L" inkscape:groupmode="layer" inkscape:label="Triggers">
+		<path id="t1" d="M0 0 L10 0 L10 10 Z"/>
+		<path id="t2" d="M100 100 L110 100 L110 110 Z"/>
+	</g>
+	<g id="r1" inkscape:label="room-1">
+		<path id="spawn-1" data-type="enemy" d="M20 20 L30 20"/>
+		<path id="spawn-2" data-type="item" d="M40 20 L40 50"/>
+	</g>
+	<path id="exit" d="M5 5 L7 9"/>
+</svg>`
+
+func TestUnmarshal(t *testing.T) {
+	var l level
+	if err := Unmarshal([]byte(levelDocument), &l, ParserOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if l.Width != 640 {
+		t.Errorf("Width = %v, expected 640", l.Width)
+	}
+	if expected := []string{"enemy", "item"}; !reflect.DeepEqual(l.SpawnTypes, expected) {
+		t.Errorf("SpawnTypes = %v, expected %v", l.SpawnTypes, expected)
+	}
+
+	// the layer selects its paths, not the layer itself
+	var triggers []string
+	for _, trigger := range l.Triggers {
+		triggers = append(triggers, trigger.ID)
+		if trigger.Shape.ID != trigger.ID || trigger.Area != trigger.Shape.Bounds() {
+			t.Errorf("trigger %s has the shape of %s and the area %v", trigger.ID, trigger.Shape.ID, trigger.Area)
+		}
+	}
+	if expected := []string{"t1", "t2"}; !reflect.DeepEqual(triggers, expected) {
+		t.Errorf("Triggers = %v, expected %v", triggers, expected)
+	}
+	if expected := (Box{Min: vector.Vector2{X: 0, Y: 0}, Max: vector.Vector2{X: 10, Y: 10}}); len(l.Triggers) > 0 && l.Triggers[0].Area != expected {
+		t.Errorf("Triggers[0].Area = %v, expected %v", l.Triggers[0].Area, expected)
+	}
+
+	if expected := (Box{Min: vector.Vector2{X: 5, Y: 5}, Max: vector.Vector2{X: 7, Y: 9}}); l.Exit != expected {
+		t.Errorf("Exit = %v, expected %v", l.Exit, expected)
+	}
+	// groups are only selected explicitly, with the geometry of all of their paths
+	if expected := []Box{{Min: vector.Vector2{X: 20, Y: 20}, Max: vector.Vector2{X: 40, Y: 50}}}; !reflect.DeepEqual(l.Rooms, expected) {
+		t.Errorf("Rooms = %v, expected %v", l.Rooms, expected)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var invalidTag struct {
+		Field string `svg:"element=circle"`
+	}
+	invalidAttribute := struct {
+		Width int `svg:"attr=width"`
+	}{Width: 7}
+	invalidBool := struct {
+		Width bool `svg:"attr=width"`
+	}{Width: true}
+
+	tests := []struct {
+		name   string
+		v      interface{}
+		target interface{}
+	}{
+		{"non-pointer", level{}, &InvalidUnmarshalError{}},
+		{"invalid element", &invalidTag, &InvalidTagError{}},
+		{"invalid attribute", &invalidAttribute, &InvalidAttributeError{}},
+		{"invalid boolean", &invalidBool, &InvalidAttributeError{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Unmarshal([]byte(`<svg width="1.5"/>`), test.v, ParserOptions{})
+			if !errors.As(err, test.target) {
+				t.Errorf("expected a %T, got %v", test.target, err)
+			}
+		})
+	}
+
+	// the fields whose attribute cannot be parsed are left untouched
+	if invalidAttribute.Width != 7 || !invalidBool.Width {
+		t.Errorf("the invalid attributes overwrote the fields with %v and %v", invalidAttribute.Width, invalidBool.Width)
+	}
+}
+
+func TestUnmarshalEmptyBox(t *testing.T) {
+	var v struct {
+		Rooms []Box `svg:"id=room-*,element=group"`
+		Empty Box   `svg:"id=empty"`
+	}
+	document := `<svg><g id="room-1"/><g id="room-2"><path d="M1 2 L3 4"/></g><path id="empty" d=""/></svg>`
+	if err := Unmarshal([]byte(document), &v, ParserOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the elements without geometry have the zero box
+	expected := []Box{{}, {Min: vector.Vector2{X: 1, Y: 2}, Max: vector.Vector2{X: 3, Y: 4}}}
+	if !reflect.DeepEqual(v.Rooms, expected) || v.Empty != (Box{}) {
+		t.Errorf("Rooms = %v and Empty = %v, expected %v and the zero box", v.Rooms, v.Empty, expected)
+	}
+}
+
+func TestUnmarshalNamespacedAttribute(t *testing.T) {
+	var v struct {
+		Kinds []string `svg:"id=*,attr=kind"`
+	}
+	for n := 0; n < 20; n++ {
+		if err := Unmarshal([]byte(namespacedDocument), &v, ParserOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(v.Kinds) != 2 || v.Kinds[0] != "second" || v.Kinds[1] != "plain" {
+			t.Fatalf("Kinds = %v, expected [second plain]", v.Kinds)
+		}
+		v.Kinds = nil
+	}
+}